@@ -0,0 +1,42 @@
+package cloudca
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetCloudCADataSourceMap return the available DataSource map
+func GetCloudCADataSourceMap() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"cloudca_load_balancer_rules":   dataSourceCloudcaLoadBalancerRules(),
+		"cloudca_port_forwarding_rules": dataSourceCloudcaPortForwardingRules(),
+		"cloudca_volume":                dataSourceCloudcaVolume(),
+		"cloudca_volumes":               dataSourceCloudcaVolumes(),
+		"cloudca_vpn":                   dataSourceCloudcaVpn(),
+	}
+}
+
+// Builds a stable ID for data sources returning a list of entities.
+func dataSourceListID(environmentID string, ids []string) string {
+	sorted := append([]string{}, ids...)
+	sort.Strings(sorted)
+	return environmentID + "-" + strconv.Itoa(schema.HashString(strings.Join(sorted, ",")))
+}
+
+// Returns true if the filter is empty or matches the value or the id (case insensitive).
+func matchesNameOrID(filter, name, id string) bool {
+	return filter == "" || strings.EqualFold(filter, name) || strings.EqualFold(filter, id)
+}
+
+// Returns true if the value is in the list (case insensitive).
+func containsIgnoreCase(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
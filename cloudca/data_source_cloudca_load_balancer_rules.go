@@ -0,0 +1,175 @@
+package cloudca
+
+import (
+	"fmt"
+	"strings"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCloudcaLoadBalancerRules() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudcaLoadBalancerRulesRead,
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of environment where the load balancer rules are located",
+			},
+			"public_ip_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return rules applied to this public IP",
+			},
+			"network_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return rules bound to this network",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return rules load balancing to this instance",
+			},
+			"public_ports": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The public ports used by the matching rules",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The load balancer rules matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_ip_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"network_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"algorithm": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_port": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_port": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"stickiness_method": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"stickiness_params": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCloudcaLoadBalancerRulesRead(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	options := map[string]string{}
+	if publicIPID, ok := d.GetOk("public_ip_id"); ok {
+		options["publicIpId"] = publicIPID.(string)
+	}
+	if networkID, ok := d.GetOk("network_id"); ok {
+		options["networkId"] = networkID.(string)
+	}
+	lbrs, err := ccaResources.LoadBalancerRules.ListWithOptions(options)
+	if err != nil {
+		return err
+	}
+
+	// The API can't filter the rules by instance, and the public IP and network filters are
+	// checked again in case the API ignores them
+	publicIPID := d.Get("public_ip_id").(string)
+	networkID := d.Get("network_id").(string)
+	instanceID := d.Get("instance_id").(string)
+
+	var ids []string
+	publicPorts := []string{}
+	rules := []map[string]interface{}{}
+	for _, lbr := range lbrs {
+		if publicIPID != "" && !strings.EqualFold(lbr.PublicIpId, publicIPID) {
+			continue
+		}
+		if networkID != "" && !strings.EqualFold(lbr.NetworkId, networkID) {
+			continue
+		}
+		if instanceID != "" && !containsIgnoreCase(lbr.InstanceIds, instanceID) {
+			continue
+		}
+		ids = append(ids, lbr.Id)
+		publicPorts = append(publicPorts, lbr.PublicPort)
+		rules = append(rules, flattenLoadBalancerRule(lbr))
+	}
+
+	d.SetId(dataSourceListID(d.Get("environment_id").(string), ids))
+	if err := d.Set("public_ports", publicPorts); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+	if err := d.Set("rules", rules); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	return nil
+}
+
+func flattenLoadBalancerRule(lbr cloudca.LoadBalancerRule) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                lbr.Id,
+		"name":              lbr.Name,
+		"public_ip_id":      lbr.PublicIpId,
+		"public_ip":         lbr.PublicIp,
+		"network_id":        lbr.NetworkId,
+		"protocol":          lbr.Protocol,
+		"algorithm":         lbr.Algorithm,
+		"public_port":       lbr.PublicPort,
+		"private_port":      lbr.PrivatePort,
+		"instance_ids":      lbr.InstanceIds,
+		"stickiness_method": lbr.StickinessMethod,
+		"stickiness_params": lbr.StickinessPolicyParameters,
+	}
+}
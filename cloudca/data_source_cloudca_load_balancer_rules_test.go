@@ -0,0 +1,55 @@
+package cloudca
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceLoadBalancerRules(t *testing.T) {
+	t.Parallel()
+
+	name := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLoadBalancerRuleCreateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceLoadBalancerRules(environmentID, vpcID, networkID, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudca_load_balancer_rules.foobar", "rules.#", "1"),
+					resource.TestCheckResourceAttr("data.cloudca_load_balancer_rules.foobar", "public_ports.0", "80"),
+					resource.TestCheckResourceAttrPair("data.cloudca_load_balancer_rules.foobar", "rules.0.id", "cloudca_load_balancer_rule.foobar", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceLoadBalancerRules(environment, vpc, network, name string) string {
+	return fmt.Sprintf(`
+resource "cloudca_public_ip" "foobar" {
+	environment_id = "%s"
+	vpc_id         = "%s"
+}
+
+resource "cloudca_load_balancer_rule" "foobar" {
+	environment_id = "%s"
+	name           = "%s"
+	network_id     = "%s"
+	public_ip_id   = cloudca_public_ip.foobar.id
+	protocol       = "tcp"
+	algorithm      = "leastconn"
+	public_port    = 80
+	private_port   = 80
+}
+
+data "cloudca_load_balancer_rules" "foobar" {
+	environment_id = "%s"
+	public_ip_id   = cloudca_load_balancer_rule.foobar.public_ip_id
+}`, environment, vpc, environment, name, network, environment)
+}
@@ -0,0 +1,196 @@
+package cloudca
+
+import (
+	"fmt"
+	"strings"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCloudcaPortForwardingRules() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudcaPortForwardingRulesRead,
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of environment where the port forwarding rules are located",
+			},
+			"public_ip_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return rules applied to this public IP",
+			},
+			"network_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return rules forwarding to this network",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return rules forwarding to this instance",
+			},
+			"public_ports": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The public port ranges used by the matching rules",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The port forwarding rules matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_ip_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_ip_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"network_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vpc_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_port_start": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_port_end": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_port_start": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_port_end": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCloudcaPortForwardingRulesRead(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	options := map[string]string{}
+	if publicIPID, ok := d.GetOk("public_ip_id"); ok {
+		options["publicIpId"] = publicIPID.(string)
+	}
+	if networkID, ok := d.GetOk("network_id"); ok {
+		options["networkId"] = networkID.(string)
+	}
+	pfrs, err := ccaResources.PortForwardingRules.ListWithOptions(options)
+	if err != nil {
+		return err
+	}
+
+	// The API can't filter the rules by instance, and the public IP and network filters are
+	// checked again in case the API ignores them
+	publicIPID := d.Get("public_ip_id").(string)
+	networkID := d.Get("network_id").(string)
+	instanceID := d.Get("instance_id").(string)
+
+	var ids []string
+	publicPorts := []string{}
+	rules := []map[string]interface{}{}
+	for _, pfr := range pfrs {
+		if publicIPID != "" && !strings.EqualFold(pfr.PublicIpId, publicIPID) {
+			continue
+		}
+		if networkID != "" && !strings.EqualFold(pfr.NetworkId, networkID) {
+			continue
+		}
+		if instanceID != "" && !strings.EqualFold(pfr.InstanceId, instanceID) {
+			continue
+		}
+		ids = append(ids, pfr.Id)
+		publicPorts = append(publicPorts, formatPortRange(pfr.PublicPortStart, pfr.PublicPortEnd))
+		rules = append(rules, flattenPortForwardingRule(pfr))
+	}
+
+	d.SetId(dataSourceListID(d.Get("environment_id").(string), ids))
+	if err := d.Set("public_ports", publicPorts); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+	if err := d.Set("rules", rules); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	return nil
+}
+
+func flattenPortForwardingRule(pfr cloudca.PortForwardingRule) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                 pfr.Id,
+		"public_ip_id":       pfr.PublicIpId,
+		"public_ip":          pfr.PublicIp,
+		"private_ip_id":      pfr.PrivateIpId,
+		"private_ip":         pfr.PrivateIp,
+		"instance_id":        pfr.InstanceId,
+		"instance_name":      pfr.InstanceName,
+		"network_id":         pfr.NetworkId,
+		"vpc_id":             pfr.VpcId,
+		"protocol":           pfr.Protocol,
+		"public_port_start":  pfr.PublicPortStart,
+		"public_port_end":    pfr.PublicPortEnd,
+		"private_port_start": pfr.PrivatePortStart,
+		"private_port_end":   pfr.PrivatePortEnd,
+		"state":              pfr.State,
+	}
+}
+
+// Formats a port range as "start-end", or "start" if the range is a single port.
+func formatPortRange(start, end string) string {
+	if end == "" || end == start {
+		return start
+	}
+	return start + "-" + end
+}
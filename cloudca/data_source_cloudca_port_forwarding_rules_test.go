@@ -0,0 +1,61 @@
+package cloudca
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourcePortForwardingRules(t *testing.T) {
+	t.Parallel()
+
+	instanceName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPortForwardingRuleCreateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePortForwardingRules(environmentID, vpcID, networkID, instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudca_port_forwarding_rules.foobar", "rules.#", "1"),
+					resource.TestCheckResourceAttr("data.cloudca_port_forwarding_rules.foobar", "public_ports.0", "80"),
+					resource.TestCheckResourceAttrPair("data.cloudca_port_forwarding_rules.foobar", "rules.0.instance_id", "cloudca_instance.foobar", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePortForwardingRules(environment, vpc, network, name string) string {
+	return fmt.Sprintf(`
+resource "cloudca_instance" "foobar" {
+	environment_id   = "%s"
+	network_id       = "%s"
+	name             = "%s"
+	template         = "Ubuntu 20.04.2"
+	compute_offering = "Standard"
+	cpu_count        = 1
+	memory_in_mb     = 1024
+}
+resource "cloudca_public_ip" "foobar" {
+	environment_id = "%s"
+	vpc_id         = "%s"
+}
+resource "cloudca_port_forwarding_rule" "foobar" {
+	environment_id     = "%s"
+	public_ip_id       = cloudca_public_ip.foobar.id
+	public_port_start  = 80
+	private_ip_id      = cloudca_instance.foobar.private_ip_id
+	private_port_start = 8080
+	protocol           = "TCP"
+}
+
+data "cloudca_port_forwarding_rules" "foobar" {
+	environment_id = "%s"
+	public_ip_id   = cloudca_port_forwarding_rule.foobar.public_ip_id
+}`, environment, network, name, environment, vpc, environment, environment)
+}
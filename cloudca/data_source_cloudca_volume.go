@@ -0,0 +1,175 @@
+package cloudca
+
+import (
+	"fmt"
+	"strings"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCloudcaVolume() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudcaVolumeRead,
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of environment where the volume is located",
+			},
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "ID of the volume",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "Name of the volume",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the volume (i.e. OS or DATA)",
+			},
+			"size_in_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the volume in gigabytes",
+			},
+			"iops": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of iops of the volume",
+			},
+			"creation_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the volume was created",
+			},
+			"disk_offering_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the disk offering of the volume",
+			},
+			"disk_offering_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the disk offering of the volume",
+			},
+			"template_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the template the volume was created from",
+			},
+			"zone_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the zone of the volume",
+			},
+			"zone_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the zone of the volume",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the volume",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the instance to which the volume is attached",
+			},
+			"instance_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the instance to which the volume is attached",
+			},
+			"instance_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the instance to which the volume is attached",
+			},
+		},
+	}
+}
+
+func dataSourceCloudcaVolumeRead(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	var volume *cloudca.Volume
+	if id, ok := d.GetOk("id"); ok {
+		found, err := ccaResources.Volumes.Get(id.(string))
+		if err != nil {
+			return handleNotFoundError("Volume", false, err, d)
+		}
+		volume = found
+	} else {
+		found, err := retrieveVolumeByName(&ccaResources, d.Get("name").(string))
+		if err != nil {
+			return err
+		}
+		volume = found
+	}
+
+	d.SetId(volume.Id)
+	for key, value := range flattenVolume(*volume) {
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf("Error reading Trigger: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func retrieveVolumeByName(ccaRes *cloudca.Resources, name string) (*cloudca.Volume, error) {
+	volumes, err := ccaRes.Volumes.List()
+	if err != nil {
+		return nil, err
+	}
+	var matches []cloudca.Volume
+	for _, volume := range volumes {
+		if strings.EqualFold(volume.Name, name) {
+			matches = append(matches, volume)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("Volume with name %s not found", name)
+	}
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("Found %d volumes with name %s, use the id instead", len(matches), name)
+	}
+	return &matches[0], nil
+}
+
+func flattenVolume(volume cloudca.Volume) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                 volume.Id,
+		"name":               volume.Name,
+		"type":               volume.Type,
+		"size_in_gb":         volume.GbSize,
+		"iops":               volume.Iops,
+		"creation_date":      volume.CreationDate,
+		"disk_offering_id":   volume.DiskOfferingId,
+		"disk_offering_name": volume.DiskOfferingName,
+		"template_id":        volume.TemplateId,
+		"zone_id":            volume.ZoneId,
+		"zone_name":          volume.ZoneName,
+		"state":              volume.State,
+		"instance_id":        volume.InstanceId,
+		"instance_name":      volume.InstanceName,
+		"instance_state":     volume.InstanceState,
+	}
+}
@@ -0,0 +1,55 @@
+package cloudca
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceVolume(t *testing.T) {
+	t.Parallel()
+
+	instanceID := "6f26111d-464d-4fc8-9c72-7a181a96c257"
+	volumeName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVolumeCreateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVolume(environmentID, instanceID, diskOfferingID, volumeName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.cloudca_volume.by_id", "id", "cloudca_volume.foobar", "id"),
+					resource.TestCheckResourceAttrPair("data.cloudca_volume.by_name", "id", "cloudca_volume.foobar", "id"),
+					resource.TestCheckResourceAttr("data.cloudca_volume.by_id", "type", "DATA"),
+					resource.TestCheckResourceAttr("data.cloudca_volume.by_id", "instance_id", instanceID),
+					resource.TestCheckResourceAttrSet("data.cloudca_volume.by_id", "creation_date"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVolume(environment, instance, diskOffering, name string) string {
+	return fmt.Sprintf(`
+resource "cloudca_volume" "foobar" {
+	environment_id = "%s"
+	name           = "%s"
+	disk_offering  = "%s"
+	instance_id    = "%s"
+	size_in_gb     = "10"
+}
+
+data "cloudca_volume" "by_id" {
+	environment_id = "%s"
+	id             = cloudca_volume.foobar.id
+}
+
+data "cloudca_volume" "by_name" {
+	environment_id = "%s"
+	name           = cloudca_volume.foobar.name
+}`, environment, name, diskOffering, instance, environment, environment)
+}
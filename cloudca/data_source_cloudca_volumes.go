@@ -0,0 +1,134 @@
+package cloudca
+
+import (
+	"fmt"
+	"strings"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCloudcaVolumes() *schema.Resource {
+	volumeSchema := dataSourceCloudcaVolume().Schema
+	delete(volumeSchema, "environment_id")
+	for _, s := range volumeSchema {
+		s.Optional = false
+		s.Computed = true
+		s.ExactlyOneOf = nil
+	}
+
+	return &schema.Resource{
+		Read: dataSourceCloudcaVolumesRead,
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of environment where the volumes are located",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return volumes of this type (i.e. OS or DATA). ROOT is accepted as an alias of OS",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return volumes attached to this instance",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return volumes in this zone (name or id)",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return volumes in this state",
+			},
+			"disk_offering": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return volumes with this disk offering (name or id)",
+			},
+			"attached": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "If set, only return volumes that are (true) or are not (false) attached to an instance",
+			},
+			"volumes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The volumes matching the filters",
+				Elem: &schema.Resource{
+					Schema: volumeSchema,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCloudcaVolumesRead(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	options := map[string]string{}
+	volumeType := normalizeVolumeType(d.Get("type").(string))
+	if volumeType != "" {
+		options["type"] = volumeType
+	}
+	volumes, err := ccaResources.Volumes.ListWithOptions(options)
+	if err != nil {
+		return err
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	zone := d.Get("zone").(string)
+	state := d.Get("state").(string)
+	diskOffering := d.Get("disk_offering").(string)
+	attached, attachedSet := d.GetOkExists("attached") //nolint:staticcheck
+
+	var ids []string
+	var flattened []map[string]interface{}
+	for _, volume := range volumes {
+		if volumeType != "" && !strings.EqualFold(volume.Type, volumeType) {
+			continue
+		}
+		if instanceID != "" && !strings.EqualFold(volume.InstanceId, instanceID) {
+			continue
+		}
+		if !matchesNameOrID(zone, volume.ZoneName, volume.ZoneId) {
+			continue
+		}
+		if state != "" && !strings.EqualFold(volume.State, state) {
+			continue
+		}
+		if !matchesNameOrID(diskOffering, volume.DiskOfferingName, volume.DiskOfferingId) {
+			continue
+		}
+		if attachedSet && attached.(bool) == (volume.InstanceId == "") {
+			continue
+		}
+		ids = append(ids, volume.Id)
+		flattened = append(flattened, flattenVolume(volume))
+	}
+
+	d.SetId(dataSourceListID(d.Get("environment_id").(string), ids))
+	if err := d.Set("volumes", flattened); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	return nil
+}
+
+// The portal refers to OS volumes as ROOT volumes, accept both.
+func normalizeVolumeType(volumeType string) string {
+	if strings.EqualFold(volumeType, "ROOT") {
+		return cloudca.VOLUME_TYPE_OS
+	}
+	return strings.ToUpper(volumeType)
+}
@@ -0,0 +1,38 @@
+package cloudca
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceVolumesRootOfInstance(t *testing.T) {
+	t.Parallel()
+
+	instanceID := "6f26111d-464d-4fc8-9c72-7a181a96c257"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVolumesRootOfInstance(environmentID, instanceID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudca_volumes.root", "volumes.#", "1"),
+					resource.TestCheckResourceAttr("data.cloudca_volumes.root", "volumes.0.type", "OS"),
+					resource.TestCheckResourceAttr("data.cloudca_volumes.root", "volumes.0.instance_id", instanceID),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVolumesRootOfInstance(environment, instance string) string {
+	return fmt.Sprintf(`
+data "cloudca_volumes" "root" {
+	environment_id = "%s"
+	type           = "ROOT"
+	instance_id    = "%s"
+}`, environment, instance)
+}
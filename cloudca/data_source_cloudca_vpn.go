@@ -0,0 +1,103 @@
+package cloudca
+
+import (
+	"fmt"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCloudcaVpn() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudcaVpnRead,
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the environment where the vpn is located",
+			},
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Id of the VPC of the vpn",
+			},
+			"certificate": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Certificate to use when using IKEV2 vpn type",
+			},
+			"preshared_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Preshared key to use when using L2TP vpn type",
+			},
+			"public_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Public IP address associated with the vpn",
+			},
+			"public_ip_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the public IP address associated with the vpn",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the vpn",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of vpn connection",
+			},
+		},
+	}
+}
+
+func dataSourceCloudcaVpnRead(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+	if rerr != nil {
+		return rerr
+	}
+
+	vpnPubIPID, err := retrieveVpnPublicIPID(&ccaResources, d.Get("vpc_id").(string))
+	if err != nil {
+		return err
+	}
+	if vpnPubIPID == "" {
+		return fmt.Errorf("No Source NAT IP was found for the VPC %s", d.Get("vpc_id").(string))
+	}
+
+	vpn, err := ccaResources.RemoteAccessVpn.Get(vpnPubIPID)
+	if err != nil {
+		return err
+	}
+	if vpn.State == "Disabled" {
+		return fmt.Errorf("The VPN of the VPC %s is disabled", d.Get("vpc_id").(string))
+	}
+
+	d.SetId(vpn.Id)
+	if err := d.Set("state", vpn.State); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+	if err := d.Set("certificate", vpn.Certificate); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+	if err := d.Set("preshared_key", vpn.PresharedKey); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+	if err := d.Set("public_ip", vpn.PublicIpAddress); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+	if err := d.Set("public_ip_id", vpn.PublicIpAddressId); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+	if err := d.Set("type", vpn.Type); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+	return nil
+}
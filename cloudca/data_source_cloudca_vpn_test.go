@@ -0,0 +1,44 @@
+package cloudca
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRemoteAccessVPN(t *testing.T) {
+	/*
+		test is run in series since it uses a vpn that changes
+		in another test
+	*/
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRemoteAccessVPNEnableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRemoteAccessVPN(environmentID, vpcID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.cloudca_vpn.foobar", "id", "cloudca_vpn.foobar", "id"),
+					resource.TestCheckResourceAttrPair("data.cloudca_vpn.foobar", "public_ip", "cloudca_vpn.foobar", "public_ip"),
+					resource.TestCheckResourceAttrPair("data.cloudca_vpn.foobar", "preshared_key", "cloudca_vpn.foobar", "preshared_key"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceRemoteAccessVPN(environment, vpc string) string {
+	return fmt.Sprintf(`
+resource "cloudca_vpn" "foobar" {
+	environment_id = "%s"
+	vpc_id         = "%s"
+}
+
+data "cloudca_vpn" "foobar" {
+	environment_id = cloudca_vpn.foobar.environment_id
+	vpc_id         = cloudca_vpn.foobar.vpc_id
+}`, environment, vpc)
+}
@@ -0,0 +1,203 @@
+package cloudca
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/api"
+	"github.com/cloud-ca/go-cloudca/services"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	fakeEnvironmentID   = "fake-environment-id"
+	fakeEnvironmentName = "fake-environment"
+	fakeServiceCode     = "fake-service"
+)
+
+// fakeAPIClient is an in-memory api.ApiClient storing entities by endpoint. Every operation
+// completes synchronously, so the task service never has to poll.
+type fakeAPIClient struct {
+	mutex    sync.Mutex
+	entities map[string]map[string]json.RawMessage
+	nextID   int
+	requests []api.CcaRequest
+}
+
+func newFakeAPIClient() *fakeAPIClient {
+	client := &fakeAPIClient{entities: map[string]map[string]json.RawMessage{}}
+	client.put("/environments", fakeEnvironmentID, map[string]interface{}{
+		"id":                fakeEnvironmentID,
+		"name":              fakeEnvironmentName,
+		"serviceConnection": map[string]interface{}{"serviceCode": fakeServiceCode},
+	})
+	return client
+}
+
+// newFakeCcaClient returns a client whose API calls are served by the fake API client
+func newFakeCcaClient() (*cca.CcaClient, *fakeAPIClient) {
+	apiClient := newFakeAPIClient()
+	return cca.NewCcaClientWithApiClient(apiClient), apiClient
+}
+
+func fakeEntityEndpoint(entityType string) string {
+	return fmt.Sprintf("/services/%s/%s/%s", fakeServiceCode, fakeEnvironmentName, entityType)
+}
+
+func (client *fakeAPIClient) put(endpoint, id string, entity interface{}) {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		panic(err)
+	}
+	if client.entities[endpoint] == nil {
+		client.entities[endpoint] = map[string]json.RawMessage{}
+	}
+	client.entities[endpoint][id] = data
+}
+
+// putEntity stores an entity of the given type in the fake environment
+func (client *fakeAPIClient) putEntity(entityType, id string, entity interface{}) {
+	client.put(fakeEntityEndpoint(entityType), id, entity)
+}
+
+// lastRequest returns the last request sent to the fake API
+func (client *fakeAPIClient) lastRequest() api.CcaRequest {
+	return client.requests[len(client.requests)-1]
+}
+
+// entityRequests returns the requests sent with the method to the entities of the given type, operations excluded
+func (client *fakeAPIClient) entityRequests(method, entityType string) []api.CcaRequest {
+	requests := []api.CcaRequest{}
+	for _, request := range client.requests {
+		if _, ok := request.Options["operation"]; ok {
+			continue
+		}
+		if endpoint, _ := splitFakeEndpoint(request.Endpoint); request.Method == method && endpoint == fakeEntityEndpoint(entityType) {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// operationRequests returns the requests executing the given operation
+func (client *fakeAPIClient) operationRequests(operation string) []api.CcaRequest {
+	requests := []api.CcaRequest{}
+	for _, request := range client.requests {
+		if request.Method == api.POST && request.Options["operation"] == operation {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// hasEntity returns true if an entity of the given type is stored in the fake environment
+func (client *fakeAPIClient) hasEntity(entityType, id string) bool {
+	_, ok := client.entities[fakeEntityEndpoint(entityType)][id]
+	return ok
+}
+
+// assertStateAttributes fails the test when an attribute of the state differs from its expected value
+func assertStateAttributes(t *testing.T, d *schema.ResourceData, expected map[string]string) {
+	t.Helper()
+	attributes := d.State().Attributes
+	for key, value := range expected {
+		if attributes[key] != value {
+			t.Fatalf("Expected %s to be %s, got %s", key, value, attributes[key])
+		}
+	}
+}
+
+func (client *fakeAPIClient) Do(request api.CcaRequest) (*api.CcaResponse, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.requests = append(client.requests, request)
+
+	endpoint, id := splitFakeEndpoint(request.Endpoint)
+
+	switch request.Method {
+	case api.GET:
+		if id == "" {
+			list := []json.RawMessage{}
+			for _, entity := range client.entities[endpoint] {
+				list = append(list, entity)
+			}
+			return client.success(list)
+		}
+		entity, ok := client.entities[endpoint][id]
+		if !ok {
+			return client.notFound(id)
+		}
+		return client.success(entity)
+	case api.POST:
+		if _, ok := request.Options["operation"]; ok {
+			return client.success(json.RawMessage("{}"))
+		}
+		client.nextID++
+		id = fmt.Sprintf("fake-id-%d", client.nextID)
+		entity := map[string]interface{}{}
+		if err := json.Unmarshal(request.Body, &entity); err != nil {
+			return nil, err
+		}
+		entity["id"] = id
+		client.put(endpoint, id, entity)
+		return client.success(client.entities[endpoint][id])
+	case api.PUT:
+		if _, ok := client.entities[endpoint][id]; !ok {
+			return client.notFound(id)
+		}
+		entity := map[string]interface{}{}
+		if err := json.Unmarshal(request.Body, &entity); err != nil {
+			return nil, err
+		}
+		entity["id"] = id
+		client.put(endpoint, id, entity)
+		return client.success(client.entities[endpoint][id])
+	case api.DELETE:
+		if _, ok := client.entities[endpoint][id]; !ok {
+			return client.notFound(id)
+		}
+		delete(client.entities[endpoint], id)
+		return client.success(json.RawMessage("{}"))
+	}
+	return nil, fmt.Errorf("Unsupported method %s", request.Method)
+}
+
+// splitFakeEndpoint splits an endpoint into its collection and the ID of the entity, if any
+func splitFakeEndpoint(endpoint string) (string, string) {
+	collectionLength := 2
+	if strings.HasPrefix(endpoint, "/services/") {
+		collectionLength = 5
+	}
+	parts := strings.Split(endpoint, "/")
+	if len(parts) <= collectionLength {
+		return endpoint, ""
+	}
+	return strings.Join(parts[:collectionLength], "/"), parts[collectionLength]
+}
+
+func (client *fakeAPIClient) success(data interface{}) (*api.CcaResponse, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &api.CcaResponse{StatusCode: api.OK, TaskStatus: services.SUCCESS, Data: body}, nil
+}
+
+func (client *fakeAPIClient) notFound(id string) (*api.CcaResponse, error) {
+	return &api.CcaResponse{
+		StatusCode: api.NOT_FOUND,
+		Errors:     []api.CcaError{{ErrorCode: "NOT_FOUND", Message: fmt.Sprintf("Entity %s not found", id)}},
+	}, nil
+}
+
+func (client *fakeAPIClient) GetApiURL() string {
+	return "https://fake.api/v1"
+}
+
+func (client *fakeAPIClient) GetApiKey() string {
+	return "fake-api-key"
+}
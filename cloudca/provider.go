@@ -25,6 +25,9 @@ func Provider() *schema.Provider {
 		ResourcesMap: mergeResourceMaps(
 			GetCloudCAResourceMap(),
 		),
+		DataSourcesMap: mergeResourceMaps(
+			GetCloudCADataSourceMap(),
+		),
 		ConfigureFunc: providerConfigure,
 	}
 }
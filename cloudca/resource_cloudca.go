@@ -1,12 +1,14 @@
 package cloudca
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"regexp"
 
 	cca "github.com/cloud-ca/go-cloudca"
 	"github.com/cloud-ca/go-cloudca/api"
+	"github.com/cloud-ca/go-cloudca/services"
 	"github.com/cloud-ca/go-cloudca/services/cloudca"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -14,20 +16,28 @@ import (
 // GetCloudCAResourceMap return the available Resource map
 func GetCloudCAResourceMap() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"cloudca_environment":          resourceCloudcaEnvironment(),
-		"cloudca_instance":             resourceCloudcaInstance(),
-		"cloudca_load_balancer_rule":   resourceCloudcaLoadBalancerRule(),
-		"cloudca_network":              resourceCloudcaNetwork(),
-		"cloudca_network_acl":          resourceCloudcaNetworkACL(),
-		"cloudca_network_acl_rule":     resourceCloudcaNetworkACLRule(),
-		"cloudca_port_forwarding_rule": resourceCloudcaPortForwardingRule(),
-		"cloudca_public_ip":            resourceCloudcaPublicIP(),
-		"cloudca_ssh_key":              resourceCloudcaSSHKey(),
-		"cloudca_static_nat":           resourceCloudcaStaticNAT(),
-		"cloudca_volume":               resourceCloudcaVolume(),
-		"cloudca_vpc":                  resourceCloudcaVpc(),
-		"cloudca_vpn":                  resourceCloudcaVpn(),
-		"cloudca_vpn_user":             resourceCloudcaVpnUser(),
+		"cloudca_affinity_group":            resourceCloudcaAffinityGroup(),
+		"cloudca_environment":               resourceCloudcaEnvironment(),
+		"cloudca_environment_role_member":   resourceCloudcaEnvironmentRoleMember(),
+		"cloudca_instance":                  resourceCloudcaInstance(),
+		"cloudca_instance_password_reset":   resourceCloudcaInstancePasswordReset(),
+		"cloudca_instance_recovery_point":   resourceCloudcaInstanceRecoveryPoint(),
+		"cloudca_load_balancer_rule":        resourceCloudcaLoadBalancerRule(),
+		"cloudca_load_balancer_rule_member": resourceCloudcaLoadBalancerRuleMember(),
+		"cloudca_network":                   resourceCloudcaNetwork(),
+		"cloudca_network_acl":               resourceCloudcaNetworkACL(),
+		"cloudca_network_acl_rule":          resourceCloudcaNetworkACLRule(),
+		"cloudca_port_forwarding_rule":      resourceCloudcaPortForwardingRule(),
+		"cloudca_public_ip":                 resourceCloudcaPublicIP(),
+		"cloudca_ssh_key":                   resourceCloudcaSSHKey(),
+		"cloudca_static_nat":                resourceCloudcaStaticNAT(),
+		"cloudca_template":                  resourceCloudcaTemplate(),
+		"cloudca_volume":                    resourceCloudcaVolume(),
+		"cloudca_volume_attachment":         resourceCloudcaVolumeAttachment(),
+		"cloudca_vpc":                       resourceCloudcaVpc(),
+		"cloudca_vpc_router_restart":        resourceCloudcaVpcRouterRestart(),
+		"cloudca_vpn":                       resourceCloudcaVpn(),
+		"cloudca_vpn_user":                  resourceCloudcaVpnUser(),
 	}
 }
 
@@ -77,3 +87,50 @@ func getResourcesForEnvironmentID(client *cca.CcaClient, environmentID string) (
 	}
 	return resources.(cloudca.Resources), nil
 }
+
+// Returns an EntityService for entity types that are not available through cloudca.Resources.
+func getEntityServiceForEnvironmentID(client *cca.CcaClient, environmentID string, entityType string) (services.EntityService, error) {
+	environment, err := client.Environments.Get(environmentID)
+	if err != nil {
+		return nil, err
+	}
+	return services.NewEntityService(client.GetApiClient(), environment.ServiceConnection.ServiceCode, environment.Name, entityType), nil
+}
+
+// Gets the entity with the given ID from the EntityService and decodes it into entity.
+func getEntity(entityService services.EntityService, id string, entity interface{}) error {
+	data, err := entityService.Get(id, map[string]string{})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, entity)
+}
+
+// Creates the entity through the EntityService and decodes the created entity into created.
+func createEntity(entityService services.EntityService, entity interface{}, created interface{}) error {
+	body, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	data, err := entityService.Create(body, map[string]string{})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, created)
+}
+
+// Updates the entity with the given ID through the EntityService.
+func updateEntity(entityService services.EntityService, id string, entity interface{}) error {
+	body, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	_, err = entityService.Update(id, body, map[string]string{})
+	return err
+}
+
+// Deletes the entity with the given ID through the EntityService.
+func deleteEntity(entityService services.EntityService, id string) error {
+	_, err := entityService.Delete(id, []byte{}, map[string]string{})
+	return err
+}
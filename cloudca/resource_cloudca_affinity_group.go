@@ -0,0 +1,126 @@
+package cloudca
+
+import (
+	"fmt"
+	"strings"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// types of affinity groups
+const (
+	HostAffinity     = "host affinity"
+	HostAntiAffinity = "host anti-affinity"
+)
+
+func resourceCloudcaAffinityGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudcaAffinityGroupCreate,
+		Read:   resourceCloudcaAffinityGroupRead,
+		Delete: resourceCloudcaAffinityGroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of environment where the affinity group should be created",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the affinity group",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Description of the affinity group",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The type of the affinity group (i.e. host affinity or host anti-affinity)",
+				ValidateFunc: validation.StringInSlice([]string{HostAffinity, HostAntiAffinity}, true),
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			"instance_ids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The ids of the instances in the affinity group",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceCloudcaAffinityGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	entityService, err := getEntityServiceForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string), cloudca.AFFINITY_GROUP_ENTITY_TYPE)
+	if err != nil {
+		return err
+	}
+
+	affinityGroupToCreate := cloudca.AffinityGroup{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Type:        d.Get("type").(string),
+	}
+	newAffinityGroup := cloudca.AffinityGroup{}
+	if err := createEntity(entityService, affinityGroupToCreate, &newAffinityGroup); err != nil {
+		return fmt.Errorf("Error creating the new affinity group %s: %s", affinityGroupToCreate.Name, err)
+	}
+	d.SetId(newAffinityGroup.Id)
+	return resourceCloudcaAffinityGroupRead(d, meta)
+}
+
+func resourceCloudcaAffinityGroupRead(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+	affinityGroup, err := ccaResources.AffinityGroups.Get(d.Id())
+	if err != nil {
+		return handleNotFoundError("Affinity group", false, err, d)
+	}
+
+	if err := d.Set("name", affinityGroup.Name); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("description", affinityGroup.Description); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("type", strings.ToLower(affinityGroup.Type)); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("instance_ids", affinityGroup.InstanceIds); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudcaAffinityGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	entityService, err := getEntityServiceForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string), cloudca.AFFINITY_GROUP_ENTITY_TYPE)
+	if err != nil {
+		return err
+	}
+	if err := deleteEntity(entityService, d.Id()); err != nil {
+		return handleNotFoundError("Affinity group", true, err, d)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cloudca
+
+import (
+	"fmt"
+	"testing"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAffinityGroupCreate(t *testing.T) {
+	t.Parallel()
+
+	name := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAffinityGroupCreateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAffinityGroupCreate(environmentID, networkID, name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAffinityGroupCreateExists("cloudca_affinity_group.foobar"),
+					resource.TestCheckResourceAttr("cloudca_instance.foobar", "affinity_group_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAffinityGroupCreate(environment, network, name string) string {
+	return fmt.Sprintf(`
+resource "cloudca_affinity_group" "foobar" {
+	environment_id = "%s"
+	name           = "%s"
+	description    = "terraform test"
+	type           = "host anti-affinity"
+}
+resource "cloudca_instance" "foobar" {
+	environment_id     = "%s"
+	network_id         = "%s"
+	name               = "%s"
+	template           = "Ubuntu 20.04.2"
+	compute_offering   = "Standard"
+	cpu_count          = 1
+	memory_in_mb       = 1024
+	affinity_group_ids = [cloudca_affinity_group.foobar.id]
+}`, environment, name, environment, network, name)
+}
+
+func testAccCheckAffinityGroupCreateExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		if rs.Primary.Attributes["environment_id"] == "" {
+			return fmt.Errorf("Environment ID is missing")
+		}
+
+		client := testAccProvider.Meta().(*cca.CcaClient)
+		resources, err := getResourcesForEnvironmentID(client, rs.Primary.Attributes["environment_id"])
+		if err != nil {
+			return err
+		}
+
+		found, err := resources.AffinityGroups.Get(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.Id != rs.Primary.ID {
+			return fmt.Errorf("Affinity group not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAffinityGroupCreateDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*cca.CcaClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "cloudca_affinity_group" {
+			if rs.Primary.Attributes["environment_id"] == "" {
+				return fmt.Errorf("Environment ID is missing")
+			}
+
+			resources, err := getResourcesForEnvironmentID(client, rs.Primary.Attributes["environment_id"])
+			if err != nil {
+				return err
+			}
+
+			_, err = resources.AffinityGroups.Get(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("Affinity group still exists")
+			}
+		}
+	}
+
+	return nil
+}
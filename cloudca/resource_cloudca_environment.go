@@ -68,19 +68,22 @@ func resourceCloudcaEnvironment() *schema.Resource {
 				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
-				Description: "List of users that will be given Environment Admin role",
+				Computed:    true,
+				Description: "List of users that will be given Environment Admin role. Conflicts with cloudca_environment_role_member resources for the same role",
 			},
 			UserRoleUsers: {
 				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
-				Description: "List of users that will be given User role",
+				Computed:    true,
+				Description: "List of users that will be given User role. Conflicts with cloudca_environment_role_member resources for the same role",
 			},
 			ReadOnlyRoleUsers: {
 				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
-				Description: "List of users that will be given Read-only role",
+				Computed:    true,
+				Description: "List of users that will be given Read-only role. Conflicts with cloudca_environment_role_member resources for the same role",
 			},
 		},
 	}
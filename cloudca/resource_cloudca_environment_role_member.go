@@ -0,0 +1,210 @@
+package cloudca
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/configuration"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Serializes role updates of the same environment, the API only allows replacing all the
+// roles of an environment at once.
+var environmentRolesLock = newMutexKV()
+
+func resourceCloudcaEnvironmentRoleMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudcaEnvironmentRoleMemberCreate,
+		Read:   resourceCloudcaEnvironmentRoleMemberRead,
+		Delete: resourceCloudcaEnvironmentRoleMemberDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudcaEnvironmentRoleMemberImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the environment",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the role to grant to the user (e.g. Environment admin, User or Read-only)",
+			},
+			"user": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID or username of the user",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the user",
+			},
+		},
+	}
+}
+
+func resourceCloudcaEnvironmentRoleMemberCreate(d *schema.ResourceData, meta interface{}) error {
+	ccaClient := meta.(*cca.CcaClient)
+	environmentID := d.Get("environment_id").(string)
+	roleName := d.Get("role").(string)
+
+	environmentRolesLock.Lock(environmentID)
+	defer environmentRolesLock.Unlock(environmentID)
+
+	environment, err := ccaClient.Environments.Get(environmentID)
+	if err != nil {
+		return err
+	}
+
+	users, err := ccaClient.Users.ListWithOptions(map[string]string{"organizationId": environment.Organization.Id})
+	if err != nil {
+		return err
+	}
+	member, err := mapUsersToRole(roleName, []interface{}{d.Get("user").(string)}, users)
+	if err != nil {
+		return err
+	}
+	userID := member.Users[0].Id
+
+	roles, err := getEnvironmentRolesWithMember(environment, roleName, userID, true, users)
+	if err != nil {
+		return err
+	}
+	if err := updateEnvironmentRoles(ccaClient, environment, roles); err != nil {
+		return fmt.Errorf("Error granting role %s to user %s in environment %s: %s", roleName, d.Get("user"), environment.Name, err)
+	}
+
+	d.SetId(environmentRoleMemberID(environmentID, roleName, userID))
+	return resourceCloudcaEnvironmentRoleMemberRead(d, meta)
+}
+
+func resourceCloudcaEnvironmentRoleMemberRead(d *schema.ResourceData, meta interface{}) error {
+	ccaClient := meta.(*cca.CcaClient)
+	environment, err := ccaClient.Environments.Get(d.Get("environment_id").(string))
+	if err != nil {
+		return handleNotFoundError("Environment role member", false, err, d)
+	}
+
+	user := d.Get("user").(string)
+	for _, role := range environment.Roles {
+		if !strings.EqualFold(role.Name, d.Get("role").(string)) {
+			continue
+		}
+		for _, roleUser := range role.Users {
+			if strings.EqualFold(roleUser.Id, user) || strings.EqualFold(roleUser.Username, user) {
+				if err := d.Set("role", role.Name); err != nil {
+					return fmt.Errorf("Error reading Trigger: %s", err)
+				}
+				if err := d.Set("user_id", roleUser.Id); err != nil {
+					return fmt.Errorf("Error reading Trigger: %s", err)
+				}
+				return nil
+			}
+		}
+	}
+
+	log.Printf("[WARN] User %s no longer has role %s in environment %s", user, d.Get("role"), environment.Name)
+	d.SetId("")
+	return nil
+}
+
+func resourceCloudcaEnvironmentRoleMemberDelete(d *schema.ResourceData, meta interface{}) error {
+	ccaClient := meta.(*cca.CcaClient)
+	environmentID := d.Get("environment_id").(string)
+	roleName := d.Get("role").(string)
+
+	environmentRolesLock.Lock(environmentID)
+	defer environmentRolesLock.Unlock(environmentID)
+
+	environment, err := ccaClient.Environments.Get(environmentID)
+	if err != nil {
+		return handleNotFoundError("Environment role member", true, err, d)
+	}
+
+	roles, err := getEnvironmentRolesWithMember(environment, roleName, d.Get("user_id").(string), false, nil)
+	if err != nil {
+		return err
+	}
+	if err := updateEnvironmentRoles(ccaClient, environment, roles); err != nil {
+		return fmt.Errorf("Error revoking role %s of user %s in environment %s: %s", roleName, d.Get("user"), environment.Name, err)
+	}
+	return nil
+}
+
+// Returns all the roles of the environment, with the user added to or removed from the given role.
+// Roles that don't exist yet in the environment are added.
+func getEnvironmentRolesWithMember(environment *configuration.Environment, roleName string, userID string, add bool, users []configuration.User) ([]configuration.Role, error) {
+	roles := []configuration.Role{}
+	roleFound := false
+	for _, envRole := range environment.Roles {
+		userIDs := []interface{}{}
+		isTargetRole := strings.EqualFold(envRole.Name, roleName)
+		for _, user := range envRole.Users {
+			if isTargetRole && strings.EqualFold(user.Id, userID) {
+				continue
+			}
+			userIDs = append(userIDs, user.Id)
+		}
+		if isTargetRole {
+			roleFound = true
+			if add {
+				userIDs = append(userIDs, userID)
+			}
+		}
+		role, err := mapUsersToRole(envRole.Name, userIDs, users)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	if !roleFound && add {
+		role, err := mapUsersToRole(roleName, []interface{}{userID}, users)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func updateEnvironmentRoles(ccaClient *cca.CcaClient, environment *configuration.Environment, roles []configuration.Role) error {
+	_, err := ccaClient.Environments.Update(environment.Id, configuration.Environment{
+		Name:              environment.Name,
+		Description:       environment.Description,
+		Organization:      configuration.Organization{Id: environment.Organization.Id},
+		ServiceConnection: configuration.ServiceConnection{Id: environment.ServiceConnection.Id},
+		Roles:             roles,
+	})
+	return err
+}
+
+func resourceCloudcaEnvironmentRoleMemberImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("Unexpected format of ID (%s), expected <environment_id>/<role>/<user_id>", d.Id())
+	}
+	if err := d.Set("environment_id", parts[0]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("role", parts[1]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("user", parts[2]); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func environmentRoleMemberID(environmentID, roleName, userID string) string {
+	return environmentID + "/" + roleName + "/" + userID
+}
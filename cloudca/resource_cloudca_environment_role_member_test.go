@@ -0,0 +1,82 @@
+package cloudca
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccEnvironmentRoleMemberCreate(t *testing.T) {
+	t.Parallel()
+
+	environmentName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckEnvironmentCreateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEnvironmentRoleMemberCreate(environmentName, "Read-only", "terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEnvironmentRoleMemberExists("cloudca_environment_role_member.foobar"),
+					resource.TestCheckResourceAttrSet("cloudca_environment_role_member.foobar", "user_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccEnvironmentRoleMemberCreate(name, role, user string) string {
+	return fmt.Sprintf(`
+resource "cloudca_environment" "foobar" {
+	organization_code = "system"
+	service_code      = "beta2r1"
+	name              = "%s"
+	description       = "Environment for %s workloads"
+}
+
+resource "cloudca_environment_role_member" "foobar" {
+	environment_id = cloudca_environment.foobar.id
+	role           = "%s"
+	user           = "%s"
+}`, name, name, role, user)
+}
+
+func testAccCheckEnvironmentRoleMemberExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*cca.CcaClient)
+
+		found, err := client.Environments.Get(rs.Primary.Attributes["environment_id"])
+		if err != nil {
+			return err
+		}
+
+		for _, role := range found.Roles {
+			if !strings.EqualFold(role.Name, rs.Primary.Attributes["role"]) {
+				continue
+			}
+			for _, user := range role.Users {
+				if user.Id == rs.Primary.Attributes["user_id"] {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("User %s does not have role %s", rs.Primary.Attributes["user"], rs.Primary.Attributes["role"])
+	}
+}
@@ -1,15 +1,37 @@
 package cloudca
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
+	"time"
 
 	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/api"
 	"github.com/cloud-ca/go-cloudca/services/cloudca"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/crypto/ssh"
 )
 
+// power states of an instance
+const (
+	instancePowerStateRunning = "running"
+	instancePowerStateStopped = "stopped"
+)
+
+// Time an instance can stay in its previous power state after being started or stopped
+const instancePowerStateGracePeriod = time.Minute
+
+// State of an instance destroyed without being purged. It can be recovered until it is purged.
+const instanceStateDestroyed = "Destroyed"
+
 func resourceCloudcaInstance() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceCloudcaInstanceCreate,
@@ -21,6 +43,13 @@ func resourceCloudcaInstance() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		CustomizeDiff: customizeInstanceDiff,
+
 		Schema: map[string]*schema.Schema{
 			"environment_id": {
 				Type:        schema.TypeString,
@@ -59,17 +88,19 @@ func resourceCloudcaInstance() *schema.Resource {
 			"ssh_key_name": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				Description: "SSH key name to attach to the new instance. Note: Cannot be used with public key.",
 			},
 			"public_key": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Public key to attach to the new instance. Note: Cannot be used with SSH key name.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Public key to attach to the new instance. Changing it associates the new key to the instance. Note: Cannot be used with SSH key name.",
+				ValidateFunc: validateSSHPublicKey,
 			},
 			"user_data": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Additional data passed to the new instance during its initialization",
+				Description: "Additional data passed to the new instance during its initialization. Changes are applied the next time the instance boots",
 			},
 			"cpu_count": {
 				Type:        schema.TypeInt,
@@ -87,8 +118,7 @@ func resourceCloudcaInstance() *schema.Resource {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Computed:    true,
-				ForceNew:    true,
-				Description: "The size of the root volume in GB. This can only be set if the template allows choosing a custom root volume size.",
+				Description: "The size of the root volume in GB. This can only be set if the template allows choosing a custom root volume size. The root volume can be grown but not shrunk.",
 			},
 			"private_ip_id": {
 				Type:     schema.TypeString,
@@ -106,6 +136,119 @@ func resourceCloudcaInstance() *schema.Resource {
 				ForceNew:    true,
 				Description: "Id of the dedicated group into which the new instance will be created",
 			},
+			"affinity_group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Ids of the affinity groups into which the new instance will be created",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The initial password of the instance. Only set if the template is password enabled",
+			},
+			"power_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The power state of the instance (i.e. running or stopped)",
+				ValidateFunc: validation.StringInSlice([]string{instancePowerStateRunning, instancePowerStateStopped}, true),
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
+			},
+			"data_disk": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "A data volume created and attached with the instance",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"disk_offering": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID or name of the disk offering of the data volume. Changing it replaces the data volume",
+							StateFunc: func(val interface{}) string {
+								return strings.ToLower(val.(string))
+							},
+						},
+						"size_in_gb": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+							Description: "The size of the data volume in GB. The disk offering must allow a custom size",
+						},
+						"iops": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+							Description: "The number of IOPS of the data volume. The disk offering must allow custom IOPS",
+						},
+						"volume_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the data volume",
+						},
+					},
+				},
+			},
+			"attach_volume_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of an existing volume to attach to the instance",
+			},
+			"purge_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Purge the instance when it is destroyed. If false, the destroyed instance can be recovered until it is purged",
+			},
+			"delete_snapshots_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the snapshots of the volumes of the instance when it is destroyed",
+			},
+			"release_public_ips_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Release every public IP associated to the instance when it is destroyed, including the public IPs managed by other resources",
+			},
+			"delete_data_volumes_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the data volume of the data_disk block when the instance is destroyed",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the instance as returned by the API (e.g. Running, Stopped)",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the zone of the instance",
+			},
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the VPC of the network of the instance",
+			},
+			"mac_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The MAC address of the instance",
+			},
+			"public_ips": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The public IP addresses associated to the instance",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -173,18 +316,53 @@ func resourceCloudcaInstanceCreate(d *schema.ResourceData, meta interface{}) err
 		instanceToCreate.DedicatedGroupId = dedicatedGroupID.(string)
 	}
 
+	if affinityGroupIDs, ok := d.GetOk("affinity_group_ids"); ok {
+		for _, id := range affinityGroupIDs.(*schema.Set).List() {
+			instanceToCreate.AffinityGroupIds = append(instanceToCreate.AffinityGroupIds, id.(string))
+		}
+	}
+
+	if dataDisks, ok := d.GetOk("data_disk"); ok {
+		if err := setInstanceDataDisk(ccaResources, &instanceToCreate, dataDisks.([]interface{})[0].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	if volumeID, ok := d.GetOk("attach_volume_id"); ok {
+		instanceToCreate.VolumeIdToAttach = volumeID.(string)
+	}
+
 	newInstance, err := ccaResources.Instances.Create(instanceToCreate)
 	if err != nil {
 		return fmt.Errorf("Error creating the new instance %s: %s", instanceToCreate.Name, err)
 	}
 
 	d.SetId(newInstance.Id)
+
+	if _, ok := d.GetOk("data_disk"); ok {
+		if err := setInstanceDataDiskVolumeID(ccaResources, d); err != nil {
+			return err
+		}
+	}
 	d.SetConnInfo(map[string]string{
 		"host":     newInstance.IpAddress,
 		"user":     newInstance.Username,
 		"password": newInstance.Password,
 	})
 
+	// The password is only returned when the instance is created, it can't be read afterwards
+	if newInstance.IsPasswordEnabled {
+		if err := d.Set("password", newInstance.Password); err != nil {
+			return fmt.Errorf("Error reading Trigger: %s", err)
+		}
+	}
+
+	if strings.EqualFold(d.Get("power_state").(string), instancePowerStateStopped) {
+		if err := setInstancePowerState(ccaResources, d.Id(), instancePowerStateStopped, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
 	return resourceCloudcaInstanceRead(d, meta)
 }
 
@@ -199,6 +377,11 @@ func resourceCloudcaInstanceRead(d *schema.ResourceData, meta interface{}) error
 	if err != nil {
 		return handleNotFoundError("Instance", false, err, d)
 	}
+	if strings.EqualFold(instance.State, instanceStateDestroyed) {
+		log.Printf("[DEBUG] Instance %s was destroyed, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
 	// Update the config
 	if err := d.Set("name", instance.Name); err != nil {
 		return fmt.Errorf("Error reading Trigger: %s", err)
@@ -216,6 +399,39 @@ func resourceCloudcaInstanceRead(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error reading Trigger: %s", err)
 	}
 
+	if err := d.Set("cpu_count", instance.CpuCount); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("memory_in_mb", instance.MemoryInMB); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("ssh_key_name", instance.SSHKeyName); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	// The user data isn't returned by every API version, it can only be compared when it is
+	if instance.UserData != "" && !instanceUserDataMatches(d.Get("user_data").(string), instance.UserData) {
+		if err := d.Set("user_data", instance.UserData); err != nil {
+			return fmt.Errorf("Error reading Trigger: %s", err)
+		}
+	}
+
+	rootVolume, err := getInstanceRootVolume(ccaResources, instance.Id)
+	if err != nil {
+		return err
+	}
+	if rootVolume != nil {
+		if err := d.Set("root_volume_size_in_gb", rootVolume.GbSize); err != nil {
+			return fmt.Errorf("Error reading Trigger: %s", err)
+		}
+	}
+
+	if err := readInstanceVolumes(ccaResources, d); err != nil {
+		return err
+	}
+
 	if err := d.Set("private_ip_id", instance.IpAddressId); err != nil {
 		return fmt.Errorf("Error reading Trigger: %s", err)
 	}
@@ -233,6 +449,319 @@ func resourceCloudcaInstanceRead(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error reading Trigger: %s", err)
 	}
 
+	// The dedicated group is also returned as an affinity group, it is managed by dedicated_group_id
+	var affinityGroupIDs []string
+	for _, affinityGroupID := range instance.AffinityGroupIds {
+		if !strings.EqualFold(affinityGroupID, dID) {
+			affinityGroupIDs = append(affinityGroupIDs, affinityGroupID)
+		}
+	}
+	if err := d.Set("affinity_group_ids", affinityGroupIDs); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	// Transitional and error states aren't power states, the power state is left as it was
+	if instance.IsRunning() || instance.IsStopped() {
+		if err := d.Set("power_state", strings.ToLower(instance.State)); err != nil {
+			return fmt.Errorf("Error reading Trigger: %s", err)
+		}
+	}
+
+	if err := d.Set("state", instance.State); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("zone", instance.ZoneName); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("vpc_id", instance.VpcId); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("mac_address", instance.MacAddress); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	var publicIPs []string
+	for _, publicIP := range instance.PublicIps {
+		publicIPs = append(publicIPs, publicIP.IpAddress)
+	}
+	if err := d.Set("public_ips", publicIPs); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	return nil
+}
+
+// Sets the data volume created with the instance, checking the disk offering allows the custom size and IOPS.
+func setInstanceDataDisk(ccaResources cloudca.Resources, instance *cloudca.Instance, dataDisk map[string]interface{}) error {
+	diskOffering, err := retrieveDiskOffering(&ccaResources, dataDisk["disk_offering"].(string))
+	if err != nil {
+		return err
+	}
+	if err := checkDataDiskOffering(diskOffering, dataDisk); err != nil {
+		return err
+	}
+	instance.AdditionalDiskOfferingId = diskOffering.Id
+	if sizeInGb := dataDisk["size_in_gb"].(int); sizeInGb != 0 {
+		instance.AdditionalDiskSizeInGb = strconv.Itoa(sizeInGb)
+	}
+	if iops := dataDisk["iops"].(int); iops != 0 {
+		instance.AdditionalDiskIops = strconv.Itoa(iops)
+	}
+	return nil
+}
+
+// Checks the disk offering allows the custom size and IOPS of the data disk.
+func checkDataDiskOffering(diskOffering *cloudca.DiskOffering, dataDisk map[string]interface{}) error {
+	if dataDisk["size_in_gb"].(int) != 0 && !diskOffering.CustomSize {
+		return fmt.Errorf("Disk offering %s doesn't allow custom size", diskOffering.Id)
+	}
+	if dataDisk["iops"].(int) != 0 && !diskOffering.CustomIops {
+		return fmt.Errorf("Disk offering %s doesn't allow custom IOPS", diskOffering.Id)
+	}
+	return nil
+}
+
+// The ID of the data volume created with the instance isn't returned, it is the data volume of the instance
+// that isn't the attached volume.
+func setInstanceDataDiskVolumeID(ccaResources cloudca.Resources, d *schema.ResourceData) error {
+	dataVolumes, err := ccaResources.Volumes.ListOfType(cloudca.VOLUME_TYPE_DATA)
+	if err != nil {
+		return fmt.Errorf("Error listing the data volumes of instance %s: %s", d.Id(), err)
+	}
+	for _, volume := range dataVolumes {
+		if strings.EqualFold(volume.InstanceId, d.Id()) && !strings.EqualFold(volume.Id, d.Get("attach_volume_id").(string)) {
+			dataDisk := d.Get("data_disk").([]interface{})[0].(map[string]interface{})
+			dataDisk["volume_id"] = volume.Id
+			if err := d.Set("data_disk", []interface{}{dataDisk}); err != nil {
+				return fmt.Errorf("Error reading Trigger: %s", err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("Data volume of instance %s not found", d.Id())
+}
+
+// Reads the data volume created with the instance and the attached volume. A volume that was deleted or
+// detached from the instance is removed from the state.
+func readInstanceVolumes(ccaResources cloudca.Resources, d *schema.ResourceData) error {
+	if dataDisks := d.Get("data_disk").([]interface{}); len(dataDisks) > 0 {
+		dataDisk := dataDisks[0].(map[string]interface{})
+		volume, err := getInstanceVolume(ccaResources, d.Id(), dataDisk["volume_id"].(string))
+		if err != nil {
+			return err
+		}
+		dataDisks = []interface{}{}
+		if volume != nil {
+			// keep the disk offering as configured when it's the ID of the disk offering
+			if !strings.EqualFold(dataDisk["disk_offering"].(string), volume.DiskOfferingId) {
+				dataDisk["disk_offering"] = strings.ToLower(volume.DiskOfferingName)
+			}
+			dataDisk["size_in_gb"] = volume.GbSize
+			dataDisk["iops"] = volume.Iops
+			dataDisks = append(dataDisks, dataDisk)
+		}
+		if err := d.Set("data_disk", dataDisks); err != nil {
+			return fmt.Errorf("Error reading Trigger: %s", err)
+		}
+	}
+
+	if volumeID := d.Get("attach_volume_id").(string); volumeID != "" {
+		volume, err := getInstanceVolume(ccaResources, d.Id(), volumeID)
+		if err != nil {
+			return err
+		}
+		if volume == nil {
+			if err := d.Set("attach_volume_id", ""); err != nil {
+				return fmt.Errorf("Error reading Trigger: %s", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the volume if it's attached to the instance, nil otherwise.
+func getInstanceVolume(ccaResources cloudca.Resources, instanceID string, volumeID string) (*cloudca.Volume, error) {
+	if volumeID == "" {
+		return nil, nil
+	}
+	volume, err := ccaResources.Volumes.Get(volumeID)
+	if err != nil {
+		if ccaError, ok := err.(api.CcaErrorResponse); ok && ccaError.StatusCode == 404 {
+			log.Printf("[DEBUG] Volume %s of instance %s was deleted", volumeID, instanceID)
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !strings.EqualFold(volume.InstanceId, instanceID) {
+		log.Printf("[DEBUG] Volume %s was detached from instance %s", volumeID, instanceID)
+		return nil, nil
+	}
+	return volume, nil
+}
+
+func resizeInstanceDataDisk(ccaResources cloudca.Resources, d *schema.ResourceData) error {
+	volumeToResize := cloudca.Volume{
+		Id:     d.Get("data_disk.0.volume_id").(string),
+		GbSize: d.Get("data_disk.0.size_in_gb").(int),
+		Iops:   d.Get("data_disk.0.iops").(int),
+	}
+	if oldSize, _ := d.GetChange("data_disk.0.size_in_gb"); oldSize.(int) > volumeToResize.GbSize {
+		return fmt.Errorf("Cannot reduce size of a volume")
+	}
+	log.Printf("[DEBUG] Resizing data volume %s of instance %s", volumeToResize.Id, d.Id())
+	if err := ccaResources.Volumes.Resize(&volumeToResize); err != nil {
+		return fmt.Errorf("Error resizing data volume %s of instance %s: %s", volumeToResize.Id, d.Id(), err)
+	}
+	return nil
+}
+
+// Applies a change of the data_disk block. Adding the block creates a data volume attached to the
+// instance and removing it detaches the data volume, which is kept. When the disk offering changes,
+// the data volume is detached and kept and a new one is created, otherwise it is resized.
+func changeInstanceDataDisk(ccaResources cloudca.Resources, d *schema.ResourceData) error {
+	oldDataDisks, newDataDisks := d.GetChange("data_disk")
+	oldVolumeID := ""
+	if len(oldDataDisks.([]interface{})) > 0 {
+		oldVolumeID = oldDataDisks.([]interface{})[0].(map[string]interface{})["volume_id"].(string)
+	}
+	// a data volume that was deleted or detached outside of Terraform is replaced
+	oldVolume, err := getInstanceVolume(ccaResources, d.Id(), oldVolumeID)
+	if err != nil {
+		return err
+	}
+
+	if len(newDataDisks.([]interface{})) == 0 {
+		if oldVolume != nil {
+			return detachInstanceDataDisk(ccaResources, d.Id(), oldVolume)
+		}
+		return nil
+	}
+	dataDisk := newDataDisks.([]interface{})[0].(map[string]interface{})
+	diskOffering, err := retrieveDiskOffering(&ccaResources, dataDisk["disk_offering"].(string))
+	if err != nil {
+		return err
+	}
+	if oldVolume != nil && strings.EqualFold(oldVolume.DiskOfferingId, diskOffering.Id) {
+		if d.HasChange("data_disk.0.size_in_gb") || d.HasChange("data_disk.0.iops") {
+			return resizeInstanceDataDisk(ccaResources, d)
+		}
+		return nil
+	}
+
+	// the size and IOPS of the old data volume are kept in the plan, the new one only gets the configured ones
+	dataDiskConfig := d.GetRawConfig().GetAttr("data_disk").Index(cty.NumberIntVal(0))
+	if dataDiskConfig.GetAttr("size_in_gb").IsNull() {
+		dataDisk["size_in_gb"] = 0
+	}
+	if dataDiskConfig.GetAttr("iops").IsNull() {
+		dataDisk["iops"] = 0
+	}
+	if err := checkDataDiskOffering(diskOffering, dataDisk); err != nil {
+		return err
+	}
+	if oldVolume != nil {
+		if err := detachInstanceDataDisk(ccaResources, d.Id(), oldVolume); err != nil {
+			return err
+		}
+	}
+	volumeToCreate := cloudca.Volume{
+		Name:           fmt.Sprintf("%s-data", d.Get("name").(string)),
+		DiskOfferingId: diskOffering.Id,
+		GbSize:         dataDisk["size_in_gb"].(int),
+		Iops:           dataDisk["iops"].(int),
+		InstanceId:     d.Id(),
+	}
+	log.Printf("[DEBUG] Creating data volume of instance %s", d.Id())
+	newVolume, err := ccaResources.Volumes.Create(volumeToCreate)
+	if err != nil {
+		return fmt.Errorf("Error creating the data volume of instance %s: %s", d.Id(), err)
+	}
+	dataDisk["volume_id"] = newVolume.Id
+	if err := d.Set("data_disk", []interface{}{dataDisk}); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+	return nil
+}
+
+// Detaches the data volume from the instance. The volume is kept, it has to be deleted separately.
+func detachInstanceDataDisk(ccaResources cloudca.Resources, instanceID string, volume *cloudca.Volume) error {
+	log.Printf("[INFO] Detaching data volume %s from instance %s, the volume is not deleted", volume.Id, instanceID)
+	if err := ccaResources.Volumes.DetachFromInstance(volume); err != nil {
+		return fmt.Errorf("Error detaching data volume %s from instance %s: %s", volume.Id, instanceID, err)
+	}
+	return nil
+}
+
+// Detaches the previously attached volume, if it's still attached, and attaches the new one.
+func changeInstanceAttachedVolume(ccaResources cloudca.Resources, d *schema.ResourceData) error {
+	oldVolumeID, newVolumeID := d.GetChange("attach_volume_id")
+	oldVolume, err := getInstanceVolume(ccaResources, d.Id(), oldVolumeID.(string))
+	if err != nil {
+		return err
+	}
+	if oldVolume != nil {
+		log.Printf("[DEBUG] Detaching volume %s from instance %s", oldVolume.Id, d.Id())
+		if err := ccaResources.Volumes.DetachFromInstance(oldVolume); err != nil {
+			return fmt.Errorf("Error detaching volume %s from instance %s: %s", oldVolume.Id, d.Id(), err)
+		}
+	}
+	if newVolumeID.(string) != "" {
+		log.Printf("[DEBUG] Attaching volume %s to instance %s", newVolumeID, d.Id())
+		if err := ccaResources.Volumes.AttachToInstance(&cloudca.Volume{Id: newVolumeID.(string)}, d.Id()); err != nil {
+			return fmt.Errorf("Error attaching volume %s to instance %s: %s", newVolumeID, d.Id(), err)
+		}
+	}
+	return nil
+}
+
+// Returns true if the user data returned by the API is the user data of the state. The API may
+// return the user data base64 encoded, the hash of both forms is compared.
+func instanceUserDataMatches(userData string, apiUserData string) bool {
+	hash := sha256.Sum256([]byte(userData))
+	if hash == sha256.Sum256([]byte(apiUserData)) {
+		return true
+	}
+	decoded, err := base64.StdEncoding.DecodeString(apiUserData)
+	return err == nil && hash == sha256.Sum256(decoded)
+}
+
+// Returns the root volume of the instance, nil if it has none.
+func getInstanceRootVolume(ccaResources cloudca.Resources, instanceID string) (*cloudca.Volume, error) {
+	rootVolumes, err := ccaResources.Volumes.ListWithOptions(map[string]string{
+		"type":       cloudca.VOLUME_TYPE_OS,
+		"instanceId": instanceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error listing the root volumes of instance %s: %s", instanceID, err)
+	}
+	for _, volume := range rootVolumes {
+		if strings.EqualFold(volume.InstanceId, instanceID) {
+			return &volume, nil
+		}
+	}
+	return nil, nil
+}
+
+// Grows the root volume of the instance to the configured size.
+func resizeInstanceRootVolume(ccaResources cloudca.Resources, d *schema.ResourceData) error {
+	rootVolume, err := getInstanceRootVolume(ccaResources, d.Id())
+	if err != nil {
+		return err
+	}
+	if rootVolume == nil {
+		return fmt.Errorf("Error resizing the root volume of instance %s: the instance has no root volume", d.Id())
+	}
+	volumeToResize := cloudca.Volume{
+		Id:     rootVolume.Id,
+		GbSize: d.Get("root_volume_size_in_gb").(int),
+	}
+	log.Printf("[DEBUG] Resizing root volume %s of instance %s", volumeToResize.Id, d.Id())
+	if err := ccaResources.Volumes.Resize(&volumeToResize); err != nil {
+		return fmt.Errorf("Error resizing root volume %s of instance %s: %s", volumeToResize.Id, d.Id(), err)
+	}
 	return nil
 }
 
@@ -244,6 +773,12 @@ func resourceCloudcaInstanceUpdate(d *schema.ResourceData, meta interface{}) err
 	}
 	d.Partial(true)
 
+	if d.HasChange("name") || d.HasChange("user_data") {
+		if err := updateInstance(d, meta); err != nil {
+			return err
+		}
+	}
+
 	if d.HasChange("compute_offering") || d.HasChange("cpu_count") || d.HasChange("memory_in_mb") {
 		newComputeOffering := d.Get("compute_offering").(string)
 		log.Printf("[DEBUG] Compute offering has changed for %s, changing compute offering...", newComputeOffering)
@@ -255,13 +790,14 @@ func resourceCloudcaInstanceUpdate(d *schema.ResourceData, meta interface{}) err
 			ComputeOfferingId: newComputeOfferingID,
 		}
 
+		// The CPU count and memory are read from the instance, they are only sent when configured
 		hasCustomFields := false
-		if cpuCount, ok := d.GetOk("cpu_count"); ok {
-			instanceToUpdate.CpuCount = cpuCount.(int)
+		if !d.GetRawConfig().GetAttr("cpu_count").IsNull() {
+			instanceToUpdate.CpuCount = d.Get("cpu_count").(int)
 			hasCustomFields = true
 		}
-		if memoryInMB, ok := d.GetOk("memory_in_mb"); ok {
-			instanceToUpdate.MemoryInMB = memoryInMB.(int)
+		if !d.GetRawConfig().GetAttr("memory_in_mb").IsNull() {
+			instanceToUpdate.MemoryInMB = d.Get("memory_in_mb").(int)
 			hasCustomFields = true
 		}
 
@@ -287,26 +823,271 @@ func resourceCloudcaInstanceUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	if d.HasChange("public_key") {
+		log.Printf("[DEBUG] Public key has changed for %s, associating new public key...", d.Id())
+		if err := associateInstancePublicKey(ccaResources, d.Id(), d.Get("name").(string), d.Get("public_key").(string)); err != nil {
+			return err
+		}
+		oldName, _ := d.GetChange("name")
+		oldPublicKey, _ := d.GetChange("public_key")
+		if err := deleteInstancePublicKey(ccaResources, oldName.(string), oldPublicKey.(string)); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("root_volume_size_in_gb") {
+		if err := resizeInstanceRootVolume(ccaResources, d); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("data_disk") {
+		if err := changeInstanceDataDisk(ccaResources, d); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("attach_volume_id") {
+		if err := changeInstanceAttachedVolume(ccaResources, d); err != nil {
+			return err
+		}
+	}
+
 	if d.HasChange("private_ip") {
 		return fmt.Errorf("Cannot update the private IP of an instance")
 	}
 
+	if d.HasChange("power_state") {
+		powerState := strings.ToLower(d.Get("power_state").(string))
+		log.Printf("[DEBUG] Power state has changed for %s, changing power state to %s...", d.Id(), powerState)
+		if err := setInstancePowerState(ccaResources, d.Id(), powerState, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
 	d.Partial(false)
 
+	return resourceCloudcaInstanceRead(d, meta)
+}
+
+// A public key can be replaced by another one but not removed, the instance is recreated instead.
+// The root volume can only be grown, shrinking it is rejected at plan time.
+func customizeInstanceDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("root_volume_size_in_gb") && d.NewValueKnown("root_volume_size_in_gb") {
+		if oldSize, newSize := d.GetChange("root_volume_size_in_gb"); newSize.(int) < oldSize.(int) {
+			return fmt.Errorf("Cannot reduce the size of the root volume of instance %s from %d GB to %d GB", d.Id(), oldSize, newSize)
+		}
+	}
+	if d.HasChange("public_key") && d.NewValueKnown("public_key") && d.Get("public_key").(string) == "" {
+		return d.ForceNew("public_key")
+	}
+	return nil
+}
+
+// Renames the instance and replaces its user data. The update replaces the whole instance, so the
+// instance is read back and sent with its other fields unchanged. The user data is always sent so
+// that it can be removed.
+func updateInstance(d *schema.ResourceData, meta interface{}) error {
+	entityService, err := getEntityServiceForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string), cloudca.INSTANCE_ENTITY_TYPE)
+	if err != nil {
+		return err
+	}
+	instance := map[string]interface{}{}
+	if err := getEntity(entityService, d.Id(), &instance); err != nil {
+		return fmt.Errorf("Error retrieving instance %s: %s", d.Id(), err)
+	}
+	instance["name"] = d.Get("name").(string)
+	instance["userData"] = d.Get("user_data").(string)
+	log.Printf("[DEBUG] Updating name and user data of instance %s", d.Id())
+	if err := updateEntity(entityService, d.Id(), instance); err != nil {
+		return fmt.Errorf("Error updating instance %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+// Associates a public key to the instance. Keys can only be associated by name, so the public key is
+// registered as an SSH key of the environment unless it already is.
+func associateInstancePublicKey(ccaResources cloudca.Resources, instanceID string, instanceName string, publicKey string) error {
+	parsedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return fmt.Errorf("Error parsing the public key of instance %s: %s", instanceID, err)
+	}
+	sshKeys, err := ccaResources.SSHKeys.List()
+	if err != nil {
+		return err
+	}
+	sshKeyName := ""
+	for _, sshKey := range sshKeys {
+		if sshKey.Fingerprint != "" && sshKeyFingerprintMatches(publicKey, sshKey.Fingerprint) {
+			sshKeyName = sshKey.Name
+			break
+		}
+	}
+	if sshKeyName == "" {
+		sshKey, err := ccaResources.SSHKeys.Create(cloudca.SSHKey{
+			Name:      getInstancePublicKeyName(instanceName, parsedKey),
+			PublicKey: publicKey,
+		})
+		if err != nil {
+			return fmt.Errorf("Error registering the public key of instance %s: %s", instanceID, err)
+		}
+		sshKeyName = sshKey.Name
+	}
+	log.Printf("[DEBUG] Associating SSH key %s to instance %s", sshKeyName, instanceID)
+	if _, err := ccaResources.Instances.AssociateSSHKey(instanceID, sshKeyName); err != nil {
+		return fmt.Errorf("Error associating SSH key %s to instance %s: %s", sshKeyName, instanceID, err)
+	}
 	return nil
 }
 
+// Deletes the SSH key registered by associateInstancePublicKey for the public key, if any. SSH keys
+// registered under another name are left untouched.
+func deleteInstancePublicKey(ccaResources cloudca.Resources, instanceName string, publicKey string) error {
+	parsedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		// the instance didn't have a public key
+		return nil
+	}
+	sshKeyName := getInstancePublicKeyName(instanceName, parsedKey)
+	sshKeys, err := ccaResources.SSHKeys.List()
+	if err != nil {
+		return err
+	}
+	for _, sshKey := range sshKeys {
+		if sshKey.Name == sshKeyName && sshKeyFingerprintMatches(publicKey, sshKey.Fingerprint) {
+			log.Printf("[DEBUG] Deleting SSH key %s previously registered for instance %s", sshKeyName, instanceName)
+			if _, err := ccaResources.SSHKeys.Delete(sshKey.ID); err != nil {
+				return fmt.Errorf("Error deleting SSH key %s: %s", sshKeyName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the name of the SSH key registered for a public key of the instance
+func getInstancePublicKeyName(instanceName string, publicKey ssh.PublicKey) string {
+	keyHash := fmt.Sprintf("%x", sha256.Sum256(publicKey.Marshal()))
+	return fmt.Sprintf("%s-%s", instanceName, keyHash[:8])
+}
+
 func resourceCloudcaInstanceDelete(d *schema.ResourceData, meta interface{}) error {
 	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
 
 	if rerr != nil {
 		return rerr
 	}
-	fmt.Printf("[INFO] Destroying instance: %s\n", d.Get("name").(string))
-	if _, err := ccaResources.Instances.Destroy(d.Id(), true); err != nil {
+	destroyOptions, err := getInstanceDestroyOptions(ccaResources, d)
+	if err != nil {
 		return handleNotFoundError("Instance", true, err, d)
 	}
+	log.Printf("[INFO] Destroying instance: %s", d.Get("name").(string))
+	if _, err := ccaResources.Instances.DestroyWithOptions(d.Id(), destroyOptions); err != nil {
+		return handleNotFoundError("Instance", true, err, d)
+	}
+
+	return deleteInstancePublicKey(ccaResources, d.Get("name").(string), d.Get("public_key").(string))
+}
+
+// Returns the options to destroy the instance with. The public IPs of the instance and the volume of
+// its data_disk are listed when they should be released or deleted with it. Volumes attached with
+// attach_volume_id or by other resources are never deleted.
+func getInstanceDestroyOptions(ccaResources cloudca.Resources, d *schema.ResourceData) (cloudca.DestroyOptions, error) {
+	destroyOptions := cloudca.DestroyOptions{
+		PurgeImmediately: d.Get("purge_on_destroy").(bool),
+		DeleteSnapshots:  d.Get("delete_snapshots_on_destroy").(bool),
+	}
+
+	if d.Get("release_public_ips_on_destroy").(bool) {
+		instance, err := ccaResources.Instances.Get(d.Id())
+		if err != nil {
+			return destroyOptions, err
+		}
+		for _, publicIP := range instance.PublicIps {
+			destroyOptions.PublicIpIdsToRelease = append(destroyOptions.PublicIpIdsToRelease, publicIP.Id)
+		}
+	}
 
+	if d.Get("delete_data_volumes_on_destroy").(bool) {
+		volume, err := getInstanceVolume(ccaResources, d.Id(), d.Get("data_disk.0.volume_id").(string))
+		if err != nil {
+			return destroyOptions, err
+		}
+		if volume != nil {
+			destroyOptions.VolumeIdsToDelete = append(destroyOptions.VolumeIdsToDelete, volume.Id)
+		}
+	}
+
+	return destroyOptions, nil
+}
+
+// Starts or stops the instance and waits until it reaches the requested power state.
+func setInstancePowerState(ccaResources cloudca.Resources, instanceID string, powerState string, timeout time.Duration) error {
+	instance, err := ccaResources.Instances.Get(instanceID)
+	if err != nil {
+		return err
+	}
+
+	// The instance can still be in its previous state right after it's started or stopped
+	var target, source string
+	switch powerState {
+	case instancePowerStateRunning:
+		target, source = cloudca.INSTANCE_STATE_RUNNING, cloudca.INSTANCE_STATE_STOPPED
+		if !instance.IsRunning() {
+			if _, err := ccaResources.Instances.Start(instanceID); err != nil {
+				return fmt.Errorf("Error starting instance %s: %s", instanceID, err)
+			}
+		}
+	case instancePowerStateStopped:
+		target, source = cloudca.INSTANCE_STATE_STOPPED, cloudca.INSTANCE_STATE_RUNNING
+		if !instance.IsStopped() {
+			if _, err := ccaResources.Instances.Stop(instanceID); err != nil {
+				return fmt.Errorf("Error stopping instance %s: %s", instanceID, err)
+			}
+		}
+	default:
+		return fmt.Errorf("Invalid power state %s", powerState)
+	}
+
+	// The previous state is only pending until the instance starts transitioning or the grace
+	// period ends, an instance going back to it or staying in it failed to change its state
+	requestedAt := time.Now()
+	transitioned := false
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Starting", "Stopping", source},
+		Target:  []string{target},
+		Refresh: func() (interface{}, string, error) {
+			instance, gErr := ccaResources.Instances.Get(instanceID)
+			if gErr != nil {
+				return nil, "", gErr
+			}
+			switch {
+			case strings.EqualFold(instance.State, target):
+				return instance, target, nil
+			case strings.EqualFold(instance.State, "Starting"), strings.EqualFold(instance.State, "Stopping"):
+				transitioned = true
+				return instance, instance.State, nil
+			case strings.EqualFold(instance.State, source):
+				if transitioned {
+					return nil, "", fmt.Errorf("instance %s went back to %s", instanceID, instance.State)
+				}
+				if time.Since(requestedAt) > instancePowerStateGracePeriod {
+					return nil, "", fmt.Errorf("instance %s is still %s after %s", instanceID, instance.State, instancePowerStateGracePeriod)
+				}
+				return instance, source, nil
+			}
+			return nil, "", fmt.Errorf("unexpected state %s of instance %s", instance.State, instanceID)
+		},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	log.Printf("[DEBUG] Waiting for instance %s to be %s", instanceID, powerState)
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for instance %s to be %s: %s", instanceID, powerState, err)
+	}
 	return nil
 }
 
@@ -0,0 +1,93 @@
+package cloudca
+
+import (
+	"fmt"
+	"log"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudcaInstancePasswordReset() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudcaInstancePasswordResetCreate,
+		Read:   resourceCloudcaInstancePasswordResetRead,
+		Delete: resourceCloudcaInstancePasswordResetDelete,
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of environment of the instance",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the instance whose password should be reset",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, will reset the password again",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The new password of the instance",
+			},
+		},
+	}
+}
+
+func resourceCloudcaInstancePasswordResetCreate(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	instance, err := ccaResources.Instances.Get(instanceID)
+	if err != nil {
+		return err
+	}
+	if !instance.IsPasswordEnabled {
+		return fmt.Errorf("Cannot reset the password of instance %s because its template is not password enabled", instance.Name)
+	}
+
+	log.Printf("[DEBUG] Resetting password of instance %s", instanceID)
+	password, err := ccaResources.Instances.ResetPassword(instanceID)
+	if err != nil {
+		return fmt.Errorf("Error resetting password of instance %s: %s", instanceID, err)
+	}
+
+	d.SetId(resource.PrefixedUniqueId(instanceID + "-"))
+	if err := d.Set("password", password); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+	return resourceCloudcaInstancePasswordResetRead(d, meta)
+}
+
+func resourceCloudcaInstancePasswordResetRead(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	if _, err := ccaResources.Instances.Get(d.Get("instance_id").(string)); err != nil {
+		return handleNotFoundError("Instance password reset", true, err, d)
+	}
+	return nil
+}
+
+func resourceCloudcaInstancePasswordResetDelete(d *schema.ResourceData, meta interface{}) error {
+	// A password reset can't be undone, it is only removed from the state
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,50 @@
+package cloudca
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccInstancePasswordReset(t *testing.T) {
+	t.Parallel()
+
+	instanceName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceCreateBasicDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstancePasswordReset(environmentID, networkID, instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("cloudca_instance.foobar", "password"),
+					resource.TestCheckResourceAttrSet("cloudca_instance_password_reset.foobar", "password"),
+				),
+			},
+		},
+	})
+}
+
+func testAccInstancePasswordReset(environment, network, name string) string {
+	return fmt.Sprintf(`
+resource "cloudca_instance" "foobar" {
+	environment_id   = "%s"
+	network_id       = "%s"
+	name             = "%s"
+	template         = "Ubuntu 20.04.2"
+	compute_offering = "Standard"
+	cpu_count        = 1
+	memory_in_mb     = 1024
+}
+resource "cloudca_instance_password_reset" "foobar" {
+	environment_id = "%s"
+	instance_id    = cloudca_instance.foobar.id
+	triggers = {
+		revision = "1"
+	}
+}`, environment, network, name, environment)
+}
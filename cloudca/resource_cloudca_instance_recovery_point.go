@@ -0,0 +1,136 @@
+package cloudca
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudcaInstanceRecoveryPoint() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudcaInstanceRecoveryPointCreate,
+		Read:   resourceCloudcaInstanceRecoveryPointRead,
+		Delete: resourceCloudcaInstanceRecoveryPointDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudcaInstanceRecoveryPointImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of environment of the instance",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the instance of which a recovery point is taken",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the recovery point",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Description of the recovery point",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, will take a new recovery point",
+			},
+		},
+	}
+}
+
+func resourceCloudcaInstanceRecoveryPointCreate(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	recoveryPoint := cloudca.RecoveryPoint{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+	log.Printf("[DEBUG] Creating recovery point %s of instance %s", recoveryPoint.Name, instanceID)
+	if _, err := ccaResources.Instances.CreateRecoveryPoint(instanceID, recoveryPoint); err != nil {
+		return fmt.Errorf("Error creating recovery point %s of instance %s: %s", recoveryPoint.Name, instanceID, err)
+	}
+
+	// The API does not return an ID for recovery points and an instance has a single one at a time,
+	// the name tells a recovery point apart from the ones that replaced it
+	d.SetId(instanceRecoveryPointID(instanceID, recoveryPoint.Name))
+	return resourceCloudcaInstanceRecoveryPointRead(d, meta)
+}
+
+func resourceCloudcaInstanceRecoveryPointRead(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	name := d.Get("name").(string)
+	instance, err := ccaResources.Instances.Get(instanceID)
+	if err != nil {
+		return handleNotFoundError("Instance recovery point", false, err, d)
+	}
+	if instance.RecoveryPoint.Name != name {
+		log.Printf("[DEBUG] Recovery point %s of instance %s was replaced or removed, removing it from the state", name, instanceID)
+		d.SetId("")
+		return nil
+	}
+	// Recovery points created before the name was part of the ID are only identified by their instance
+	d.SetId(instanceRecoveryPointID(instanceID, name))
+
+	if err := d.Set("description", instance.RecoveryPoint.Description); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+	return nil
+}
+
+func resourceCloudcaInstanceRecoveryPointDelete(d *schema.ResourceData, meta interface{}) error {
+	// Recovery points can't be deleted through the API, they are only removed from the state
+	log.Printf("[INFO] Removing recovery point %s of instance %s from the state", d.Get("name").(string), d.Get("instance_id").(string))
+	d.SetId("")
+	return nil
+}
+
+func resourceCloudcaInstanceRecoveryPointImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("Unexpected format of ID (%s), expected <environment_id>/<instance_id>/<name>", d.Id())
+	}
+	if err := d.Set("environment_id", parts[0]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("instance_id", parts[1]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("name", parts[2]); err != nil {
+		return nil, err
+	}
+	d.SetId(instanceRecoveryPointID(parts[1], parts[2]))
+	return []*schema.ResourceData{d}, nil
+}
+
+func instanceRecoveryPointID(instanceID, name string) string {
+	return instanceID + "/" + name
+}
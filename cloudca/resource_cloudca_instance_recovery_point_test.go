@@ -0,0 +1,58 @@
+package cloudca
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccInstanceRecoveryPointCreate(t *testing.T) {
+	t.Parallel()
+
+	instanceName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceCreateBasicDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceRecoveryPointCreate(environmentID, networkID, instanceName, "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("cloudca_instance_recovery_point.foobar", "instance_id", "cloudca_instance.foobar", "id"),
+					resource.TestCheckResourceAttr("cloudca_instance_recovery_point.foobar", "name", instanceName+"-v1"),
+				),
+			},
+			{
+				Config: testAccInstanceRecoveryPointCreate(environmentID, networkID, instanceName, "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudca_instance_recovery_point.foobar", "triggers.version", "v2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccInstanceRecoveryPointCreate(environment, network, name, version string) string {
+	return fmt.Sprintf(`
+resource "cloudca_instance" "foobar" {
+	environment_id   = "%s"
+	network_id       = "%s"
+	name             = "%s"
+	template         = "Ubuntu 20.04.2"
+	compute_offering = "Standard"
+	cpu_count        = 1
+	memory_in_mb     = 1024
+}
+resource "cloudca_instance_recovery_point" "foobar" {
+	environment_id = "%s"
+	instance_id    = cloudca_instance.foobar.id
+	name           = "%s-%s"
+	description    = "terraform test"
+	triggers = {
+		version = "%s"
+	}
+}`, environment, network, name, environment, name, version, version)
+}
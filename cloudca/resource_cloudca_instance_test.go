@@ -1,13 +1,22 @@
 package cloudca
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/api"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"golang.org/x/crypto/ssh"
 )
 
 const cloudcaInstance = "cloudca_instance"
@@ -53,6 +62,525 @@ func TestAccInstanceCreateDataDrive(t *testing.T) {
 	})
 }
 
+func TestAccInstanceInlineDataDisk(t *testing.T) {
+	t.Parallel()
+
+	instanceName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceCreateBasicDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceInlineDataDisk(environmentID, networkID, instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceCreateBasicExists("cloudca_instance.foobar"),
+					resource.TestCheckResourceAttr("cloudca_instance.foobar", "data_disk.0.size_in_gb", "10"),
+					resource.TestCheckResourceAttrSet("cloudca_instance.foobar", "data_disk.0.volume_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstancePowerState(t *testing.T) {
+	t.Parallel()
+
+	instanceName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceCreateBasicDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstancePowerState(environmentID, networkID, instanceName, "stopped"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceCreateBasicExists("cloudca_instance.foobar"),
+					resource.TestCheckResourceAttr("cloudca_instance.foobar", "power_state", "stopped"),
+				),
+			},
+			{
+				Config: testAccInstancePowerState(environmentID, networkID, instanceName, "running"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceCreateBasicExists("cloudca_instance.foobar"),
+					resource.TestCheckResourceAttr("cloudca_instance.foobar", "power_state", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceCloudcaInstanceRead(t *testing.T) {
+	client, apiClient := newFakeCcaClient()
+	apiClient.putEntity(cloudca.INSTANCE_ENTITY_TYPE, "instance-id", cloudca.Instance{
+		Id:                  "instance-id",
+		Name:                "my-instance",
+		State:               cloudca.INSTANCE_STATE_RUNNING,
+		ComputeOfferingName: "Standard",
+		CpuCount:            4,
+		MemoryInMB:          8192,
+		SSHKeyName:          "portal-key",
+		UserData:            base64.StdEncoding.EncodeToString([]byte("#cloud-config")),
+		ZoneName:            "QC-2",
+		VpcId:               "vpc-id",
+		MacAddress:          "02:00:12:34:56:78",
+		PublicIps:           []cloudca.PublicIp{{Id: "public-ip-id", IpAddress: "172.31.3.4"}},
+	})
+	apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "root-volume-id", cloudca.Volume{
+		Id:         "root-volume-id",
+		Type:       cloudca.VOLUME_TYPE_OS,
+		GbSize:     120,
+		InstanceId: "instance-id",
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceCloudcaInstance().Schema, map[string]interface{}{
+		"environment_id":   fakeEnvironmentID,
+		"compute_offering": "standard",
+		"cpu_count":        2,
+		"user_data":        "#cloud-config",
+	})
+	d.SetId("instance-id")
+	if err := resourceCloudcaInstanceRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	assertStateAttributes(t, d, map[string]string{
+		"cpu_count":              "4",
+		"memory_in_mb":           "8192",
+		"ssh_key_name":           "portal-key",
+		"user_data":              "#cloud-config",
+		"root_volume_size_in_gb": "120",
+		"state":                  cloudca.INSTANCE_STATE_RUNNING,
+		"zone":                   "QC-2",
+		"vpc_id":                 "vpc-id",
+		"mac_address":            "02:00:12:34:56:78",
+		"public_ips.#":           "1",
+		"public_ips.0":           "172.31.3.4",
+	})
+}
+
+func TestInstanceUserDataMatches(t *testing.T) {
+	cases := []struct {
+		userData    string
+		apiUserData string
+		matches     bool
+	}{
+		{"#cloud-config", "#cloud-config", true},
+		{"#cloud-config", base64.StdEncoding.EncodeToString([]byte("#cloud-config")), true},
+		{"#cloud-config", "#!/bin/bash", false},
+		{"", base64.StdEncoding.EncodeToString([]byte("#cloud-config")), false},
+	}
+	for _, c := range cases {
+		if matches := instanceUserDataMatches(c.userData, c.apiUserData); matches != c.matches {
+			t.Fatalf("Expected %q and %q to match: %t, got %t", c.userData, c.apiUserData, c.matches, matches)
+		}
+	}
+}
+
+func TestResourceCloudcaInstanceDeleteWithOptions(t *testing.T) {
+	client, apiClient := newFakeCcaClient()
+	apiClient.putEntity(cloudca.INSTANCE_ENTITY_TYPE, "instance-id", cloudca.Instance{
+		Id:        "instance-id",
+		Name:      "my-instance",
+		PublicIps: []cloudca.PublicIp{{Id: "public-ip-id", IpAddress: "172.31.3.4"}},
+	})
+	apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "data-volume-id", cloudca.Volume{Id: "data-volume-id", Type: cloudca.VOLUME_TYPE_DATA, InstanceId: "instance-id"})
+	// attached by another resource, it isn't deleted with the instance
+	apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "attached-volume-id", cloudca.Volume{Id: "attached-volume-id", Type: cloudca.VOLUME_TYPE_DATA, InstanceId: "instance-id"})
+	apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "other-volume-id", cloudca.Volume{Id: "other-volume-id", Type: cloudca.VOLUME_TYPE_DATA, InstanceId: "other-instance-id"})
+
+	d := schema.TestResourceDataRaw(t, resourceCloudcaInstance().Schema, map[string]interface{}{
+		"environment_id":                 fakeEnvironmentID,
+		"name":                           "my-instance",
+		"purge_on_destroy":               false,
+		"delete_snapshots_on_destroy":    true,
+		"release_public_ips_on_destroy":  true,
+		"delete_data_volumes_on_destroy": true,
+	})
+	d.SetId("instance-id")
+	if err := d.Set("data_disk", []interface{}{map[string]interface{}{"disk_offering": "performance", "volume_id": "data-volume-id"}}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := resourceCloudcaInstanceDelete(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	request := apiClient.lastRequest()
+	if request.Method != api.DELETE {
+		t.Fatalf("Expected a DELETE request, got %s", request.Method)
+	}
+	body := cloudca.DestroyOptions{}
+	if err := json.Unmarshal(request.Body, &body); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if body.PurgeImmediately || !body.DeleteSnapshots ||
+		len(body.PublicIpIdsToRelease) != 1 || body.PublicIpIdsToRelease[0] != "public-ip-id" ||
+		len(body.VolumeIdsToDelete) != 1 || body.VolumeIdsToDelete[0] != "data-volume-id" {
+		t.Fatalf("Unexpected request body: %s", request.Body)
+	}
+}
+
+func TestResourceCloudcaInstanceReadDestroyed(t *testing.T) {
+	client, apiClient := newFakeCcaClient()
+	apiClient.putEntity(cloudca.INSTANCE_ENTITY_TYPE, "instance-id", cloudca.Instance{Id: "instance-id", State: instanceStateDestroyed})
+
+	d := schema.TestResourceDataRaw(t, resourceCloudcaInstance().Schema, map[string]interface{}{
+		"environment_id": fakeEnvironmentID,
+	})
+	d.SetId("instance-id")
+	if err := resourceCloudcaInstanceRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "" {
+		t.Fatalf("Expected the destroyed instance to be removed from the state")
+	}
+}
+
+func TestResourceCloudcaInstanceCreateWithVolumes(t *testing.T) {
+	client, apiClient := newFakeCcaClient()
+	apiClient.putEntity(cloudca.COMPUTE_OFFERING_ENTITY_TYPE, "compute-offering-id", cloudca.ComputeOffering{Id: "compute-offering-id", Name: "Standard"})
+	apiClient.putEntity(cloudca.TEMPLATE_ENTITY_TYPE, "template-id", cloudca.Template{ID: "template-id", Name: "Ubuntu"})
+	apiClient.putEntity(cloudca.DISK_OFFERING_ENTITY_TYPE, "disk-offering-id", cloudca.DiskOffering{Id: "disk-offering-id", Name: "Performance", CustomSize: true})
+	// the fake API doesn't create the volumes of the instance, they are attached to the ID of the created instance
+	apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "data-volume-id", cloudca.Volume{Id: "data-volume-id", Type: cloudca.VOLUME_TYPE_DATA, InstanceId: "fake-id-1", DiskOfferingId: "disk-offering-id", DiskOfferingName: "Performance", GbSize: 50})
+	apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "attached-volume-id", cloudca.Volume{Id: "attached-volume-id", Type: cloudca.VOLUME_TYPE_DATA, InstanceId: "fake-id-1"})
+
+	d := schema.TestResourceDataRaw(t, resourceCloudcaInstance().Schema, map[string]interface{}{
+		"environment_id":   fakeEnvironmentID,
+		"name":             "my-instance",
+		"template":         "ubuntu",
+		"compute_offering": "standard",
+		"network_id":       "network-id",
+		"data_disk": []interface{}{map[string]interface{}{
+			"disk_offering": "Performance",
+			"size_in_gb":    50,
+		}},
+		"attach_volume_id": "attached-volume-id",
+	})
+	if err := resourceCloudcaInstanceCreate(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, request := range apiClient.entityRequests(api.POST, cloudca.INSTANCE_ENTITY_TYPE) {
+		body := cloudca.Instance{}
+		if err := json.Unmarshal(request.Body, &body); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if body.AdditionalDiskOfferingId != "disk-offering-id" || body.AdditionalDiskSizeInGb != "50" || body.VolumeIdToAttach != "attached-volume-id" {
+			t.Fatalf("Unexpected request body: %s", request.Body)
+		}
+	}
+
+	assertStateAttributes(t, d, map[string]string{
+		"data_disk.#":               "1",
+		"data_disk.0.disk_offering": "performance",
+		"data_disk.0.size_in_gb":    "50",
+		"data_disk.0.volume_id":     "data-volume-id",
+		"attach_volume_id":          "attached-volume-id",
+	})
+
+	// volumes detached from the instance are removed from the state
+	apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "attached-volume-id", cloudca.Volume{Id: "attached-volume-id", Type: cloudca.VOLUME_TYPE_DATA})
+	apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "data-volume-id", cloudca.Volume{Id: "data-volume-id", Type: cloudca.VOLUME_TYPE_DATA})
+	if err := resourceCloudcaInstanceRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(d.Get("data_disk").([]interface{})) != 0 || d.Get("attach_volume_id").(string) != "" {
+		t.Fatalf("Expected the detached volumes to be removed from the state, got %#v", d.State().Attributes)
+	}
+}
+
+func TestResourceCloudcaInstanceUpdateNameAndUserData(t *testing.T) {
+	client, apiClient := newFakeCcaClient()
+	apiClient.putEntity(cloudca.INSTANCE_ENTITY_TYPE, "instance-id", cloudca.Instance{
+		Id:                "instance-id",
+		Name:              "old-name",
+		UserData:          "old-data",
+		ComputeOfferingId: "compute-offering-id",
+		NetworkId:         "network-id",
+		SSHKeyName:        "my-key",
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceCloudcaInstance().Schema, map[string]interface{}{
+		"environment_id": fakeEnvironmentID,
+		"name":           "new-name",
+	})
+	d.SetId("instance-id")
+	if err := updateInstance(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	request := apiClient.lastRequest()
+	if request.Method != api.PUT {
+		t.Fatalf("Expected a PUT request, got %s", request.Method)
+	}
+	body := map[string]interface{}{}
+	if err := json.Unmarshal(request.Body, &body); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	// the other fields of the instance are sent back unchanged
+	if body["name"] != "new-name" || body["userData"] != "" || body["computeOfferingId"] != "compute-offering-id" ||
+		body["networkId"] != "network-id" || body["sshKeyName"] != "my-key" {
+		t.Fatalf("Unexpected request body: %s", request.Body)
+	}
+}
+
+func TestResourceCloudcaInstanceResizeRootVolume(t *testing.T) {
+	client, apiClient := newFakeCcaClient()
+	apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "other-root-volume-id", cloudca.Volume{Id: "other-root-volume-id", Type: cloudca.VOLUME_TYPE_OS, GbSize: 50, InstanceId: "other-instance-id"})
+	apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "root-volume-id", cloudca.Volume{Id: "root-volume-id", Type: cloudca.VOLUME_TYPE_OS, GbSize: 50, InstanceId: "instance-id"})
+	resources, err := getResourcesForEnvironmentID(client, fakeEnvironmentID)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCloudcaInstance().Schema, map[string]interface{}{
+		"environment_id":         fakeEnvironmentID,
+		"root_volume_size_in_gb": 100,
+	})
+	d.SetId("instance-id")
+	if err := resizeInstanceRootVolume(resources, d); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	requests := apiClient.operationRequests("resize")
+	if len(requests) != 1 {
+		t.Fatalf("Expected the root volume to be resized once, got %d resize requests", len(requests))
+	}
+	body := cloudca.Volume{}
+	if err := json.Unmarshal(requests[0].Body, &body); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !strings.HasSuffix(requests[0].Endpoint, "/root-volume-id") || body.GbSize != 100 {
+		t.Fatalf("Unexpected resize request of %s: %s", requests[0].Endpoint, requests[0].Body)
+	}
+}
+
+func TestResourceCloudcaInstanceRootVolumeCannotShrink(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "instance-id",
+		Attributes: map[string]string{
+			"environment_id":         fakeEnvironmentID,
+			"root_volume_size_in_gb": "100",
+		},
+	}
+	cases := []struct {
+		size  int
+		valid bool
+	}{
+		{100, true},
+		{200, true},
+		{50, false},
+	}
+	for _, c := range cases {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"environment_id":         fakeEnvironmentID,
+			"root_volume_size_in_gb": c.size,
+		})
+		diff, err := resourceCloudcaInstance().Diff(context.Background(), state, config, nil)
+		if valid := err == nil; valid != c.valid {
+			t.Fatalf("Expected a root volume of %d GB to be valid: %t, got %v", c.size, c.valid, err)
+		}
+		if err == nil && diff != nil && diff.RequiresNew() {
+			t.Fatalf("Expected the root volume of %d GB to be resized in place", c.size)
+		}
+	}
+}
+
+func TestResourceCloudcaInstanceChangeDataDisk(t *testing.T) {
+	attachedDataDisk := map[string]string{
+		"data_disk.#":               "1",
+		"data_disk.0.disk_offering": "performance",
+		"data_disk.0.size_in_gb":    "50",
+		"data_disk.0.iops":          "0",
+		"data_disk.0.volume_id":     "data-volume-id",
+	}
+	cases := []struct {
+		name             string
+		state            map[string]string
+		dataDisk         map[string]interface{}
+		createdVolumes   int
+		detachedVolumes  int
+		resizedVolumes   int
+		expectedVolumeID string
+	}{
+		{"add", map[string]string{}, map[string]interface{}{"disk_offering": "Performance", "size_in_gb": 50}, 1, 0, 0, "fake-id-1"},
+		{"remove", attachedDataDisk, nil, 0, 1, 0, ""},
+		{"change disk offering", attachedDataDisk, map[string]interface{}{"disk_offering": "fast"}, 1, 1, 0, "fake-id-1"},
+		{"resize", attachedDataDisk, map[string]interface{}{"disk_offering": "performance", "size_in_gb": 100}, 0, 0, 1, "data-volume-id"},
+		{"re-add after a detach outside of Terraform", map[string]string{"data_disk.#": "1", "data_disk.0.disk_offering": "performance", "data_disk.0.volume_id": "detached-volume-id"}, map[string]interface{}{"disk_offering": "performance"}, 1, 0, 0, "fake-id-1"},
+	}
+	for _, c := range cases {
+		client, apiClient := newFakeCcaClient()
+		apiClient.putEntity(cloudca.DISK_OFFERING_ENTITY_TYPE, "performance-id", cloudca.DiskOffering{Id: "performance-id", Name: "Performance", CustomSize: true})
+		apiClient.putEntity(cloudca.DISK_OFFERING_ENTITY_TYPE, "fast-id", cloudca.DiskOffering{Id: "fast-id", Name: "Fast"})
+		apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "data-volume-id", cloudca.Volume{Id: "data-volume-id", Type: cloudca.VOLUME_TYPE_DATA, DiskOfferingId: "performance-id", GbSize: 50, InstanceId: "instance-id"})
+		apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "detached-volume-id", cloudca.Volume{Id: "detached-volume-id", Type: cloudca.VOLUME_TYPE_DATA, DiskOfferingId: "performance-id", GbSize: 50})
+		resources, err := getResourcesForEnvironmentID(client, fakeEnvironmentID)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		state := &terraform.InstanceState{ID: "instance-id", Attributes: map[string]string{"environment_id": fakeEnvironmentID, "name": "my-instance"}}
+		for key, value := range c.state {
+			state.Attributes[key] = value
+		}
+		config := map[string]interface{}{"environment_id": fakeEnvironmentID, "name": "my-instance"}
+		if c.dataDisk != nil {
+			config["data_disk"] = []interface{}{c.dataDisk}
+		}
+		d := testInstanceResourceDataUpdate(t, state, config)
+		if err := changeInstanceDataDisk(resources, d); err != nil {
+			t.Fatalf("%s: err: %s", c.name, err)
+		}
+
+		createRequests := apiClient.entityRequests(api.POST, cloudca.VOLUME_ENTITY_TYPE)
+		if len(createRequests) != c.createdVolumes {
+			t.Fatalf("%s: expected %d created volumes, got %d", c.name, c.createdVolumes, len(createRequests))
+		}
+		for _, request := range createRequests {
+			body := cloudca.Volume{}
+			if err := json.Unmarshal(request.Body, &body); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if body.InstanceId != "instance-id" || !strings.EqualFold(body.DiskOfferingId, c.dataDisk["disk_offering"].(string)+"-id") {
+				t.Fatalf("%s: unexpected request body: %s", c.name, request.Body)
+			}
+		}
+		if detached := len(apiClient.operationRequests("detachFromInstance")); detached != c.detachedVolumes {
+			t.Fatalf("%s: expected %d detached volumes, got %d", c.name, c.detachedVolumes, detached)
+		}
+		if resized := len(apiClient.operationRequests("resize")); resized != c.resizedVolumes {
+			t.Fatalf("%s: expected %d resized volumes, got %d", c.name, c.resizedVolumes, resized)
+		}
+		if !apiClient.hasEntity(cloudca.VOLUME_ENTITY_TYPE, "data-volume-id") {
+			t.Fatalf("%s: expected the old data volume to be kept", c.name)
+		}
+		if c.dataDisk != nil && d.Get("data_disk.0.volume_id").(string) != c.expectedVolumeID {
+			t.Fatalf("%s: expected the data volume to be %s, got %s", c.name, c.expectedVolumeID, d.Get("data_disk.0.volume_id"))
+		}
+	}
+}
+
+// testInstanceResourceDataUpdate returns the resource data of an update of the instance from its state to the config
+func testInstanceResourceDataUpdate(t *testing.T, state *terraform.InstanceState, config map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	diff, err := resourceCloudcaInstance().Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("Expected the instance to be updated in place, got %#v", diff)
+	}
+	rawConfig, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if diff.RawConfig, err = ctyjson.Unmarshal(rawConfig, schema.InternalMap(resourceCloudcaInstance().Schema).CoreConfigSchema().ImpliedType()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	d, err := schema.InternalMap(resourceCloudcaInstance().Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return d
+}
+
+func TestResourceCloudcaInstanceAssociatePublicKey(t *testing.T) {
+	client, apiClient := newFakeCcaClient()
+	resources, err := getResourcesForEnvironmentID(client, fakeEnvironmentID)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	publicKey, _, err := generateSSHKeyPair(SSHKeyAlgorithmED25519, 0)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := associateInstancePublicKey(resources, "instance-id", "my-instance", publicKey); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertAssociatedSSHKey(t, apiClient, "my-instance-")
+	sshKeys, err := resources.SSHKeys.List()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(sshKeys) != 1 || sshKeys[0].PublicKey != publicKey {
+		t.Fatalf("Expected the public key to be registered, got %+v", sshKeys)
+	}
+
+	// a public key already registered in the environment is associated by its name
+	parsedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	apiClient.putEntity(cloudca.SSH_KEY_ENTITY_TYPE, sshKeys[0].ID, cloudca.SSHKey{
+		ID:          sshKeys[0].ID,
+		Name:        "existing-key",
+		PublicKey:   publicKey,
+		Fingerprint: ssh.FingerprintLegacyMD5(parsedKey),
+	})
+	if err := associateInstancePublicKey(resources, "instance-id", "my-instance", publicKey); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertAssociatedSSHKey(t, apiClient, "existing-key")
+	if sshKeys, _ := resources.SSHKeys.List(); len(sshKeys) != 1 {
+		t.Fatalf("Expected the registered public key to be reused, got %+v", sshKeys)
+	}
+
+	// only the SSH keys registered for the instance are deleted when its public key is rotated
+	rotatedKey, _, err := generateSSHKeyPair(SSHKeyAlgorithmED25519, 0)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := associateInstancePublicKey(resources, "instance-id", "my-instance", rotatedKey); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := deleteInstancePublicKey(resources, "my-instance", publicKey); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if sshKeys, _ := resources.SSHKeys.List(); len(sshKeys) != 2 {
+		t.Fatalf("Expected the SSH key registered by the user to be kept, got %+v", sshKeys)
+	}
+	if err := deleteInstancePublicKey(resources, "my-instance", rotatedKey); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if sshKeys, _ := resources.SSHKeys.List(); len(sshKeys) != 1 || sshKeys[0].Name != "existing-key" {
+		t.Fatalf("Expected the SSH key registered for the instance to be deleted, got %+v", sshKeys)
+	}
+}
+
+// asserts the last request associated an SSH key whose name starts with the prefix
+func assertAssociatedSSHKey(t *testing.T, apiClient *fakeAPIClient, sshKeyPrefix string) {
+	request := apiClient.lastRequest()
+	if request.Options["operation"] != cloudca.INSTANCE_ASSOCIATE_SSH_KEY_OPERATION {
+		t.Fatalf("Expected the SSH key to be associated, got %+v", request)
+	}
+	body := cloudca.Instance{}
+	if err := json.Unmarshal(request.Body, &body); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !strings.HasPrefix(body.SSHKeyName, sshKeyPrefix) {
+		t.Fatalf("Expected SSH key %s to be associated, got %s", sshKeyPrefix, body.SSHKeyName)
+	}
+}
+
+func testAccInstancePowerState(environment, network, name, powerState string) string {
+	return fmt.Sprintf(`
+resource %s "foobar" {
+	environment_id   = "%s"
+	network_id       = "%s"
+	name             = "%s"
+	template         = "Ubuntu 20.04.2"
+	compute_offering = "Standard"
+	cpu_count        = 1
+	memory_in_mb     = 1024
+	power_state      = "%s"
+}`, cloudcaInstance, environment, network, name, powerState)
+}
+
 func testAccInstanceCreateBasic(environment, network, name string) string {
 	return fmt.Sprintf(`
 resource %s "foobar" {
@@ -66,6 +594,25 @@ resource %s "foobar" {
 }`, cloudcaInstance, environment, network, name)
 }
 
+func testAccInstanceInlineDataDisk(environment, network, name string) string {
+	return fmt.Sprintf(`
+resource %s "foobar" {
+	environment_id                 = "%s"
+	network_id                     = "%s"
+	name                           = "%s"
+	template                       = "Ubuntu 20.04.2"
+	compute_offering               = "Standard"
+	cpu_count                      = 1
+	memory_in_mb                   = 1024
+	delete_data_volumes_on_destroy = true
+
+	data_disk {
+		disk_offering = "Performance, No QoS"
+		size_in_gb    = 10
+	}
+}`, cloudcaInstance, environment, network, name)
+}
+
 func testAccInstanceCreateDataDrive(environment, network, name string) string {
 	return fmt.Sprintf(`
 resource %s "foobar" {
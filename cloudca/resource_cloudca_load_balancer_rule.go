@@ -1,20 +1,24 @@
 package cloudca
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	cca "github.com/cloud-ca/go-cloudca"
 	"github.com/cloud-ca/go-cloudca/services/cloudca"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCloudcaLoadBalancerRule() *schema.Resource {
 	return &schema.Resource{
-		Create: createLbr,
-		Read:   readLbr,
-		Delete: deleteLbr,
-		Update: updateLbr,
+		Create:        createLbr,
+		Read:          readLbr,
+		Delete:        deleteLbr,
+		UpdateContext: updateLbr,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -75,7 +79,8 @@ func resourceCloudcaLoadBalancerRule() *schema.Resource {
 			"instance_ids": {
 				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "List of instance ids that will be load balanced",
+				Computed:    true,
+				Description: "List of instance ids that will be load balanced. Setting it conflicts with cloudca_load_balancer_rule_member resources on the same rule",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"stickiness_method": {
@@ -207,13 +212,15 @@ func deleteLbr(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func updateLbr(d *schema.ResourceData, meta interface{}) error {
+func updateLbr(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
 
 	if rerr != nil {
-		return rerr
+		return diag.FromErr(rerr)
 	}
 
+	var diags diag.Diagnostics
+
 	d.Partial(true)
 
 	if d.HasChange("stickiness_method") || d.HasChange("stickiness_params") {
@@ -225,16 +232,16 @@ func updateLbr(d *schema.ResourceData, meta interface{}) error {
 			}
 			err := ccaResources.LoadBalancerRules.SetLoadBalancerRuleStickinessPolicy(d.Id(), stickinessMethod.(string), stickinessPolicyParameters)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 
 			if _, ok := d.GetOk("stickiness_params"); ok {
-				return errors.New("Stickiness params should be removed if the stickiness method is removed")
+				return diag.FromErr(errors.New("Stickiness params should be removed if the stickiness method is removed"))
 			}
 			err := ccaResources.LoadBalancerRules.RemoveLoadBalancerRuleStickinessPolicy(d.Id())
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -244,11 +251,15 @@ func updateLbr(d *schema.ResourceData, meta interface{}) error {
 		newAlgorithm := d.Get("algorithm").(string)
 		_, err := ccaResources.LoadBalancerRules.Update(cloudca.LoadBalancerRule{Id: d.Id(), Name: newName, Algorithm: newAlgorithm})
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if d.HasChange("instance_ids") {
+		oldInstanceIds, newInstanceIds := d.GetChange("instance_ids")
+		if removed := oldInstanceIds.(*schema.Set).Difference(newInstanceIds.(*schema.Set)); removed.Len() > 0 {
+			diags = append(diags, authoritativeLbrInstancesWarning(d, removed))
+		}
 		var instanceIds []string
 		for _, id := range d.Get("instance_ids").(*schema.Set).List() {
 			instanceIds = append(instanceIds, id.(string))
@@ -256,11 +267,31 @@ func updateLbr(d *schema.ResourceData, meta interface{}) error {
 
 		instanceErr := ccaResources.LoadBalancerRules.SetLoadBalancerRuleInstances(d.Id(), instanceIds)
 		if instanceErr != nil {
-			return instanceErr
+			return append(diags, diag.FromErr(instanceErr)...)
 		}
 	}
 	d.Partial(false)
-	return readLbr(d, meta)
+	if err := readLbr(d, meta); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+// instance_ids replaces the whole list of instances of the rule, which removes any instance
+// added with cloudca_load_balancer_rule_member.
+func authoritativeLbrInstancesWarning(d *schema.ResourceData, removed *schema.Set) diag.Diagnostic {
+	var instanceIds []string
+	for _, id := range removed.List() {
+		instanceIds = append(instanceIds, id.(string))
+	}
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Instances removed from load balancer rule %s", d.Get("name")),
+		Detail: fmt.Sprintf("instance_ids is authoritative, the instances %s were removed from the rule. "+
+			"Instances added with cloudca_load_balancer_rule_member are removed as well when they are not in instance_ids.",
+			strings.Join(instanceIds, ", ")),
+		AttributePath: cty.GetAttrPath("instance_ids"),
+	}
 }
 
 func getStickinessPolicyParameterMap(policyMap map[string]interface{}) map[string]string {
@@ -0,0 +1,183 @@
+package cloudca
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Serializes membership updates of the same load balancer rule, the API only allows
+// replacing the whole list of instances.
+var loadBalancerRuleMembersLock = newMutexKV()
+
+func resourceCloudcaLoadBalancerRuleMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudcaLoadBalancerRuleMemberCreate,
+		Read:   resourceCloudcaLoadBalancerRuleMemberRead,
+		Delete: resourceCloudcaLoadBalancerRuleMemberDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudcaLoadBalancerRuleMemberImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of environment where the load balancer rule is located",
+			},
+			"rule_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the load balancer rule",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the instance to add to the load balancer rule",
+			},
+		},
+	}
+}
+
+func resourceCloudcaLoadBalancerRuleMemberCreate(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	ruleID := d.Get("rule_id").(string)
+	instanceID := d.Get("instance_id").(string)
+
+	loadBalancerRuleMembersLock.Lock(ruleID)
+	defer loadBalancerRuleMembersLock.Unlock(ruleID)
+
+	lbr, err := ccaResources.LoadBalancerRules.Get(ruleID)
+	if err != nil {
+		return err
+	}
+
+	if containsIgnoreCase(lbr.InstanceIds, instanceID) {
+		log.Printf("[DEBUG] Instance %s is already a member of load balancer rule %s", instanceID, ruleID)
+	} else {
+		instanceIds := append(lbr.InstanceIds, instanceID)
+		if err := ccaResources.LoadBalancerRules.SetLoadBalancerRuleInstances(ruleID, instanceIds); err != nil {
+			return fmt.Errorf("Error adding instance %s to load balancer rule %s: %s", instanceID, ruleID, err)
+		}
+	}
+
+	d.SetId(loadBalancerRuleMemberID(ruleID, instanceID))
+	return resourceCloudcaLoadBalancerRuleMemberRead(d, meta)
+}
+
+func resourceCloudcaLoadBalancerRuleMemberRead(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	lbr, err := ccaResources.LoadBalancerRules.Get(d.Get("rule_id").(string))
+	if err != nil {
+		return handleNotFoundError("Load balancer rule member", false, err, d)
+	}
+
+	if !containsIgnoreCase(lbr.InstanceIds, d.Get("instance_id").(string)) {
+		log.Printf("[WARN] Instance %s is no longer a member of load balancer rule %s, it may have been removed by a "+
+			"cloudca_load_balancer_rule managing instance_ids", d.Get("instance_id"), lbr.Id)
+		d.SetId("")
+		return nil
+	}
+
+	return nil
+}
+
+func resourceCloudcaLoadBalancerRuleMemberDelete(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	ruleID := d.Get("rule_id").(string)
+	instanceID := d.Get("instance_id").(string)
+
+	loadBalancerRuleMembersLock.Lock(ruleID)
+	defer loadBalancerRuleMembersLock.Unlock(ruleID)
+
+	lbr, err := ccaResources.LoadBalancerRules.Get(ruleID)
+	if err != nil {
+		return handleNotFoundError("Load balancer rule member", true, err, d)
+	}
+
+	var instanceIds []string
+	for _, id := range lbr.InstanceIds {
+		if !strings.EqualFold(id, instanceID) {
+			instanceIds = append(instanceIds, id)
+		}
+	}
+	if len(instanceIds) == len(lbr.InstanceIds) {
+		log.Printf("[DEBUG] Instance %s is not a member of load balancer rule %s", instanceID, ruleID)
+		return nil
+	}
+
+	if len(instanceIds) > 0 {
+		err = ccaResources.LoadBalancerRules.SetLoadBalancerRuleInstances(ruleID, instanceIds)
+	} else {
+		err = removeAllLoadBalancerRuleInstances(meta.(*cca.CcaClient), d.Get("environment_id").(string), ruleID)
+	}
+	if err != nil {
+		return fmt.Errorf("Error removing instance %s from load balancer rule %s: %s", instanceID, ruleID, err)
+	}
+	return nil
+}
+
+// SetLoadBalancerRuleInstances omits an empty list of instances from the request, so the
+// last member has to be removed by sending the empty list explicitly.
+func removeAllLoadBalancerRuleInstances(client *cca.CcaClient, environmentID string, ruleID string) error {
+	entityService, err := getEntityServiceForEnvironmentID(client, environmentID, cloudca.LOAD_BALANCER_RULE_ENTITY_TYPE)
+	if err != nil {
+		return err
+	}
+	msg, err := json.Marshal(map[string]interface{}{
+		"id":          ruleID,
+		"instanceIds": []string{},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = entityService.Execute(ruleID, cloudca.UPDATE_INSTANCES, msg, map[string]string{})
+	return err
+}
+
+func resourceCloudcaLoadBalancerRuleMemberImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("Unexpected format of ID (%s), expected <environment_id>/<rule_id>/<instance_id>", d.Id())
+	}
+	if err := d.Set("environment_id", parts[0]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("rule_id", parts[1]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("instance_id", parts[2]); err != nil {
+		return nil, err
+	}
+	d.SetId(loadBalancerRuleMemberID(parts[1], parts[2]))
+	return []*schema.ResourceData{d}, nil
+}
+
+func loadBalancerRuleMemberID(ruleID, instanceID string) string {
+	return ruleID + "/" + instanceID
+}
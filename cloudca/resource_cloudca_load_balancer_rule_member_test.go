@@ -0,0 +1,136 @@
+package cloudca
+
+import (
+	"fmt"
+	"testing"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLoadBalancerRuleMemberCreate(t *testing.T) {
+	t.Parallel()
+
+	instanceName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLoadBalancerRuleMemberDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLoadBalancerRuleMemberCreate(environmentID, vpcID, networkID, instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLoadBalancerRuleMemberExists("cloudca_load_balancer_rule_member.first"),
+					testAccCheckLoadBalancerRuleMemberExists("cloudca_load_balancer_rule_member.second"),
+				),
+			},
+		},
+	})
+}
+
+func testAccLoadBalancerRuleMemberCreate(environment, vpc, network, name string) string {
+	return fmt.Sprintf(`
+resource "cloudca_instance" "first" {
+	environment_id   = "%[1]s"
+	network_id       = "%[3]s"
+	name             = "%[4]s-1"
+	template         = "Ubuntu 20.04.2"
+	compute_offering = "Standard"
+	cpu_count        = 1
+	memory_in_mb     = 1024
+}
+resource "cloudca_instance" "second" {
+	environment_id   = "%[1]s"
+	network_id       = "%[3]s"
+	name             = "%[4]s-2"
+	template         = "Ubuntu 20.04.2"
+	compute_offering = "Standard"
+	cpu_count        = 1
+	memory_in_mb     = 1024
+}
+resource "cloudca_public_ip" "foobar" {
+	environment_id = "%[1]s"
+	vpc_id         = "%[2]s"
+}
+resource "cloudca_load_balancer_rule" "foobar" {
+	environment_id = "%[1]s"
+	network_id     = "%[3]s"
+	name           = "%[4]s"
+	public_ip_id   = cloudca_public_ip.foobar.id
+	protocol       = "tcp"
+	algorithm      = "leastconn"
+	public_port    = 80
+	private_port   = 80
+}
+resource "cloudca_load_balancer_rule_member" "first" {
+	environment_id = "%[1]s"
+	rule_id        = cloudca_load_balancer_rule.foobar.id
+	instance_id    = cloudca_instance.first.id
+}
+resource "cloudca_load_balancer_rule_member" "second" {
+	environment_id = "%[1]s"
+	rule_id        = cloudca_load_balancer_rule.foobar.id
+	instance_id    = cloudca_instance.second.id
+}`, environment, vpc, network, name)
+}
+
+func testAccCheckLoadBalancerRuleMemberExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		if rs.Primary.Attributes["environment_id"] == "" {
+			return fmt.Errorf("Environment ID is missing")
+		}
+
+		client := testAccProvider.Meta().(*cca.CcaClient)
+		resources, err := getResourcesForEnvironmentID(client, rs.Primary.Attributes["environment_id"])
+		if err != nil {
+			return err
+		}
+
+		found, err := resources.LoadBalancerRules.Get(rs.Primary.Attributes["rule_id"])
+		if err != nil {
+			return err
+		}
+
+		if !containsIgnoreCase(found.InstanceIds, rs.Primary.Attributes["instance_id"]) {
+			return fmt.Errorf("Instance %s is not a member of the load balancer rule", rs.Primary.Attributes["instance_id"])
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckLoadBalancerRuleMemberDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*cca.CcaClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "cloudca_load_balancer_rule_member" {
+			if rs.Primary.Attributes["environment_id"] == "" {
+				return fmt.Errorf("Environment ID is missing")
+			}
+
+			resources, err := getResourcesForEnvironmentID(client, rs.Primary.Attributes["environment_id"])
+			if err != nil {
+				return err
+			}
+
+			found, err := resources.LoadBalancerRules.Get(rs.Primary.Attributes["rule_id"])
+			if err == nil && containsIgnoreCase(found.InstanceIds, rs.Primary.Attributes["instance_id"]) {
+				return fmt.Errorf("Instance is still a member of the load balancer rule")
+			}
+		}
+	}
+
+	return nil
+}
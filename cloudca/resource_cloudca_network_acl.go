@@ -1,7 +1,10 @@
 package cloudca
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"strings"
 
 	cca "github.com/cloud-ca/go-cloudca"
 	"github.com/cloud-ca/go-cloudca/services/cloudca"
@@ -12,8 +15,11 @@ func resourceCloudcaNetworkACL() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceCloudcaNetworkACLCreate,
 		Read:   resourceCloudcaNetworkACLRead,
+		Update: resourceCloudcaNetworkACLUpdate,
 		Delete: resourceCloudcaNetworkACLDelete,
 
+		CustomizeDiff: customizeNetworkACLDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -43,6 +49,73 @@ func resourceCloudcaNetworkACL() *schema.Resource {
 				ForceNew:    true,
 				Description: "Id of the VPC",
 			},
+			"rule": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				// Allows rule = [] to delete all the rules, omitting rule leaves them unmanaged
+				ConfigMode:  schema.SchemaConfigModeAttr,
+				Description: "The rules of the network ACL. When set, the rules are managed authoritatively and rules not defined here are deleted",
+				Set:         networkACLRuleHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rule_number": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The rule number of network ACL",
+						},
+						"cidr": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The network ACL rule cidr",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The network ACL rule action (i.e. Allow or Deny)",
+							StateFunc: func(val interface{}) string {
+								return strings.ToLower(val.(string))
+							},
+						},
+						"protocol": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The network ACL rule protocol (i.e. TCP, UDP, ICMP or All)",
+							StateFunc: func(val interface{}) string {
+								return strings.ToLower(val.(string))
+							},
+						},
+						"traffic_type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The network ACL rule traffc type (i.e. Ingress or Egress)",
+							StateFunc: func(val interface{}) string {
+								return strings.ToLower(val.(string))
+							},
+						},
+						"icmp_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ICMP type. Can only be used with ICMP protocol.",
+						},
+						"icmp_code": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ICMP code. Can only be used with ICMP protocol.",
+						},
+						"start_port": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The start port. Can only be used with TCP/UDP protocol.",
+						},
+						"end_port": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The end port. Can only be used with TCP/UDP protocol.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -64,6 +137,12 @@ func resourceCloudcaNetworkACLCreate(d *schema.ResourceData, meta interface{}) e
 		return fmt.Errorf("Error creating the new network ACL %s: %s", aclToCreate.Name, err)
 	}
 	d.SetId(newACL.Id)
+
+	if !d.GetRawConfig().GetAttr("rule").IsNull() {
+		if err := reconcileNetworkACLRules(ccaResources, d.Id(), d.Get("rule").(*schema.Set)); err != nil {
+			return err
+		}
+	}
 	return resourceCloudcaNetworkACLRead(d, meta)
 }
 
@@ -91,9 +170,45 @@ func resourceCloudcaNetworkACLRead(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("Error reading Trigger: %s", err)
 	}
 
+	aclRules, rErr := ccaResources.NetworkAclRules.ListByNetworkAclId(d.Id())
+	if rErr != nil {
+		return rErr
+	}
+
+	knownRuleNumbers := map[string]bool{}
+	for _, rule := range d.Get("rule").(*schema.Set).List() {
+		knownRuleNumbers[rule.(map[string]interface{})["rule_number"].(string)] = true
+	}
+	rules := make([]interface{}, 0, len(aclRules))
+	for _, aclRule := range aclRules {
+		if len(knownRuleNumbers) > 0 && !knownRuleNumbers[aclRule.RuleNumber] {
+			log.Printf("[WARN] Network ACL %s has a rule %s (id=%s) that was not created by this resource, it will be deleted if the rules are managed inline", d.Id(), aclRule.RuleNumber, aclRule.Id)
+		}
+		rules = append(rules, flattenNetworkACLRule(aclRule))
+	}
+	if err := d.Set("rule", rules); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
 	return nil
 }
 
+func resourceCloudcaNetworkACLUpdate(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	if d.HasChange("rule") {
+		if err := reconcileNetworkACLRules(ccaResources, d.Id(), d.Get("rule").(*schema.Set)); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudcaNetworkACLRead(d, meta)
+}
+
 func resourceCloudcaNetworkACLDelete(d *schema.ResourceData, meta interface{}) error {
 	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
 
@@ -105,3 +220,165 @@ func resourceCloudcaNetworkACLDelete(d *schema.ResourceData, meta interface{}) e
 	}
 	return nil
 }
+
+func customizeNetworkACLDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	ruleNumbers := map[string]bool{}
+	for _, raw := range d.Get("rule").(*schema.Set).List() {
+		rule := expandNetworkACLRule(raw.(map[string]interface{}))
+		// the rule number and the protocol are required, they are only empty when not known yet
+		if rule.RuleNumber == "" || rule.Protocol == "" {
+			continue
+		}
+		if ruleNumbers[rule.RuleNumber] {
+			return fmt.Errorf("Duplicate rule number %s in network ACL %s", rule.RuleNumber, d.Get("name"))
+		}
+		ruleNumbers[rule.RuleNumber] = true
+		if err := validateNetworkACLRule(rule); err != nil {
+			return fmt.Errorf("Invalid rule %s: %s", rule.RuleNumber, err)
+		}
+	}
+	return nil
+}
+
+// Makes the rules of the network ACL match the desired rules. Rules are matched on their rule
+// number: modified rules are updated, missing rules are created and unknown rules are deleted
+// last so that the network ACL never lacks a rule that is kept. A rule whose protocol changes
+// can only be deleted and created again.
+func reconcileNetworkACLRules(ccaResources cloudca.Resources, networkACLID string, desired *schema.Set) error {
+	desiredRules := map[string]cloudca.NetworkAclRule{}
+	for _, raw := range desired.List() {
+		rule := expandNetworkACLRule(raw.(map[string]interface{}))
+		rule.NetworkAclId = networkACLID
+		desiredRules[rule.RuleNumber] = rule
+	}
+
+	existingRules, err := ccaResources.NetworkAclRules.ListByNetworkAclId(networkACLID)
+	if err != nil {
+		return err
+	}
+
+	var toCreate []cloudca.NetworkAclRule
+	var toUpdate []cloudca.NetworkAclRule
+	var toDelete []cloudca.NetworkAclRule
+	var toReplace []cloudca.NetworkAclRule
+	existingByNumber := map[string]cloudca.NetworkAclRule{}
+	for _, existing := range existingRules {
+		existingByNumber[existing.RuleNumber] = existing
+		rule, ok := desiredRules[existing.RuleNumber]
+		switch {
+		case !ok:
+			toDelete = append(toDelete, existing)
+		case !strings.EqualFold(rule.Protocol, existing.Protocol):
+			// the protocol of a rule cannot be updated
+			rule.Id = existing.Id
+			toReplace = append(toReplace, rule)
+		case !networkACLRulesEqual(rule, existing):
+			rule.Id = existing.Id
+			toUpdate = append(toUpdate, rule)
+		}
+	}
+	for number, rule := range desiredRules {
+		if _, ok := existingByNumber[number]; !ok {
+			toCreate = append(toCreate, rule)
+		}
+	}
+
+	for _, rule := range toUpdate {
+		log.Printf("[DEBUG] Updating rule %s (id=%s) of network ACL %s", rule.RuleNumber, rule.Id, networkACLID)
+		if _, err := ccaResources.NetworkAclRules.Update(rule.Id, rule); err != nil {
+			return fmt.Errorf("Error updating the network ACL rule %s: %s", rule.RuleNumber, err)
+		}
+	}
+	for _, rule := range toCreate {
+		if err := createNetworkACLRule(ccaResources, networkACLID, rule); err != nil {
+			return err
+		}
+	}
+	for _, rule := range toDelete {
+		if err := deleteNetworkACLRule(ccaResources, networkACLID, rule); err != nil {
+			return err
+		}
+	}
+	for _, rule := range toReplace {
+		if err := deleteNetworkACLRule(ccaResources, networkACLID, rule); err != nil {
+			return err
+		}
+		rule.Id = ""
+		if err := createNetworkACLRule(ccaResources, networkACLID, rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createNetworkACLRule(ccaResources cloudca.Resources, networkACLID string, rule cloudca.NetworkAclRule) error {
+	log.Printf("[DEBUG] Creating rule %s of network ACL %s", rule.RuleNumber, networkACLID)
+	if _, err := ccaResources.NetworkAclRules.Create(rule); err != nil {
+		return fmt.Errorf("Error creating the new network ACL rule %s: %s", rule.RuleNumber, err)
+	}
+	return nil
+}
+
+func deleteNetworkACLRule(ccaResources cloudca.Resources, networkACLID string, rule cloudca.NetworkAclRule) error {
+	log.Printf("[DEBUG] Deleting rule %s (id=%s) of network ACL %s", rule.RuleNumber, rule.Id, networkACLID)
+	if _, err := ccaResources.NetworkAclRules.Delete(rule.Id); err != nil {
+		return fmt.Errorf("Error deleting the network ACL rule %s: %s", rule.RuleNumber, err)
+	}
+	return nil
+}
+
+func expandNetworkACLRule(raw map[string]interface{}) cloudca.NetworkAclRule {
+	return cloudca.NetworkAclRule{
+		RuleNumber:  raw["rule_number"].(string),
+		Cidr:        raw["cidr"].(string),
+		Action:      raw["action"].(string),
+		Protocol:    raw["protocol"].(string),
+		TrafficType: raw["traffic_type"].(string),
+		IcmpType:    raw["icmp_type"].(string),
+		IcmpCode:    raw["icmp_code"].(string),
+		StartPort:   raw["start_port"].(string),
+		EndPort:     raw["end_port"].(string),
+	}
+}
+
+func flattenNetworkACLRule(rule cloudca.NetworkAclRule) map[string]interface{} {
+	return map[string]interface{}{
+		"rule_number":  rule.RuleNumber,
+		"cidr":         rule.Cidr,
+		"action":       strings.ToLower(rule.Action),
+		"protocol":     strings.ToLower(rule.Protocol),
+		"traffic_type": strings.ToLower(rule.TrafficType),
+		"icmp_type":    rule.IcmpType,
+		"icmp_code":    rule.IcmpCode,
+		"start_port":   rule.StartPort,
+		"end_port":     rule.EndPort,
+	}
+}
+
+func networkACLRulesEqual(a, b cloudca.NetworkAclRule) bool {
+	return a.RuleNumber == b.RuleNumber &&
+		a.Cidr == b.Cidr &&
+		strings.EqualFold(a.Action, b.Action) &&
+		strings.EqualFold(a.Protocol, b.Protocol) &&
+		strings.EqualFold(a.TrafficType, b.TrafficType) &&
+		a.IcmpType == b.IcmpType &&
+		a.IcmpCode == b.IcmpCode &&
+		a.StartPort == b.StartPort &&
+		a.EndPort == b.EndPort
+}
+
+// Hashes a rule ignoring the case of the fields that are lower cased in the state.
+func networkACLRuleHash(v interface{}) int {
+	rule := expandNetworkACLRule(v.(map[string]interface{}))
+	return schema.HashString(strings.Join([]string{
+		rule.RuleNumber,
+		rule.Cidr,
+		strings.ToLower(rule.Action),
+		strings.ToLower(rule.Protocol),
+		strings.ToLower(rule.TrafficType),
+		rule.IcmpType,
+		rule.IcmpCode,
+		rule.StartPort,
+		rule.EndPort,
+	}, "-"))
+}
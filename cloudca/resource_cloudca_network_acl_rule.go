@@ -115,11 +115,8 @@ func resourceCloudcaNetworkACLRuleCreate(d *schema.ResourceData, meta interface{
 	}
 	fillPortFields(d, &aclRuleToCreate)
 	fillIcmpFields(d, &aclRuleToCreate)
-	if !(strings.EqualFold(TCP, aclRuleToCreate.Protocol) || strings.EqualFold(UDP, aclRuleToCreate.Protocol)) && (aclRuleToCreate.StartPort != "" || aclRuleToCreate.EndPort != "") {
-		return fmt.Errorf("Cannot have ports if not TCP or UDP protocol")
-	}
-	if !strings.EqualFold(ICMP, aclRuleToCreate.Protocol) && (aclRuleToCreate.IcmpType != "" || aclRuleToCreate.IcmpCode != "") {
-		return fmt.Errorf("Cannot have icmp fields if not ICMP protocol")
+	if err := validateNetworkACLRule(aclRuleToCreate); err != nil {
+		return err
 	}
 
 	newACLRule, err := ccaResources.NetworkAclRules.Create(aclRuleToCreate)
@@ -216,6 +213,16 @@ func resourceCloudcaNetworkACLRuleDelete(d *schema.ResourceData, meta interface{
 	return nil
 }
 
+func validateNetworkACLRule(aclRule cloudca.NetworkAclRule) error {
+	if !(strings.EqualFold(TCP, aclRule.Protocol) || strings.EqualFold(UDP, aclRule.Protocol)) && (aclRule.StartPort != "" || aclRule.EndPort != "") {
+		return fmt.Errorf("Cannot have ports if not TCP or UDP protocol")
+	}
+	if !strings.EqualFold(ICMP, aclRule.Protocol) && (aclRule.IcmpType != "" || aclRule.IcmpCode != "") {
+		return fmt.Errorf("Cannot have icmp fields if not ICMP protocol")
+	}
+	return nil
+}
+
 func fillPortFields(d *schema.ResourceData, aclRule *cloudca.NetworkAclRule) {
 	if v, ok := d.GetOk("start_port"); ok {
 		aclRule.StartPort = v.(string)
@@ -30,6 +30,82 @@ func TestAccNetworkACLCreate(t *testing.T) {
 	})
 }
 
+func TestAccNetworkACLInlineRules(t *testing.T) {
+	t.Parallel()
+
+	networkACLName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkACLCreateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkACLInlineRules(environmentID, vpcID, networkACLName, "10.0.0.0/8"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkACLCreateExists("cloudca_network_acl.foobar"),
+					resource.TestCheckResourceAttr("cloudca_network_acl.foobar", "rule.#", "2"),
+				),
+			},
+			{
+				Config: testAccNetworkACLInlineRules(environmentID, vpcID, networkACLName, "192.168.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkACLCreateExists("cloudca_network_acl.foobar"),
+					resource.TestCheckResourceAttr("cloudca_network_acl.foobar", "rule.#", "2"),
+				),
+			},
+			{
+				Config: testAccNetworkACLNoRules(environmentID, vpcID, networkACLName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkACLCreateExists("cloudca_network_acl.foobar"),
+					resource.TestCheckResourceAttr("cloudca_network_acl.foobar", "rule.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNetworkACLInlineRules(environment, vpc, name, cidr string) string {
+	return fmt.Sprintf(`
+resource "cloudca_network_acl" "foobar" {
+	environment_id = "%s"
+	vpc_id         = "%s"
+	name           = "%s"
+	description    = "This is a %s acl"
+
+	rule {
+		rule_number  = "10"
+		cidr         = "%s"
+		action       = "Allow"
+		protocol     = "TCP"
+		start_port   = "22"
+		end_port     = "22"
+		traffic_type = "Ingress"
+	}
+
+	rule {
+		rule_number  = "20"
+		cidr         = "0.0.0.0/0"
+		action       = "Allow"
+		protocol     = "ICMP"
+		icmp_type    = "-1"
+		icmp_code    = "-1"
+		traffic_type = "Ingress"
+	}
+}`, environment, vpc, name, name, cidr)
+}
+
+func testAccNetworkACLNoRules(environment, vpc, name string) string {
+	return fmt.Sprintf(`
+resource "cloudca_network_acl" "foobar" {
+	environment_id = "%s"
+	vpc_id         = "%s"
+	name           = "%s"
+	description    = "This is a %s acl"
+	rule           = []
+}`, environment, vpc, name, name)
+}
+
 func testAccNetworkACLCreate(environment, vpc, name string) string {
 	return fmt.Sprintf(`
 resource "cloudca_network_acl" "foobar" {
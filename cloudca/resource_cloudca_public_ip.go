@@ -2,6 +2,7 @@ package cloudca
 
 import (
 	"fmt"
+	"strings"
 
 	cca "github.com/cloud-ca/go-cloudca"
 	"github.com/cloud-ca/go-cloudca/services/cloudca"
@@ -26,15 +27,61 @@ func resourceCloudcaPublicIP() *schema.Resource {
 				Description: "ID of environment where the public IP should be created",
 			},
 			"vpc_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"vpc_id", "network_id"},
+				Description:  "Id of the VPC",
+			},
+			"network_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Id of the network, for networks that are not attached to a VPC",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "Id of the VPC",
+				Description: "Name or id of the zone where the public IP should be acquired",
+				StateFunc: func(val interface{}) string {
+					return strings.ToLower(val.(string))
+				},
 			},
 			"ip_address": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the public IP",
+			},
+			"purposes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The purposes of the public IP (e.g. STATIC_NAT, PORT_FORWARDING or LOAD_BALANCING)",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ports": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The ports used by the rules of the public IP",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the instance the public IP is statically NATed to",
+			},
+			"zone_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the zone of the public IP",
+			},
 		},
 	}
 }
@@ -45,10 +92,21 @@ func resourceCloudcaPublicIPCreate(d *schema.ResourceData, meta interface{}) err
 	if rerr != nil {
 		return rerr
 	}
-	vpcID := d.Get("vpc_id").(string)
-
 	publicIPToCreate := cloudca.PublicIp{
-		VpcId: vpcID,
+		VpcId:     d.Get("vpc_id").(string),
+		NetworkId: d.Get("network_id").(string),
+	}
+
+	if zone, ok := d.GetOk("zone"); ok {
+		if isID(zone.(string)) {
+			publicIPToCreate.ZoneId = zone.(string)
+		} else {
+			zoneID, err := retrieveZoneID(&ccaResources, zone.(string))
+			if err != nil {
+				return err
+			}
+			publicIPToCreate.ZoneId = zoneID
+		}
 	}
 	newPublicIP, err := ccaResources.PublicIps.Acquire(publicIPToCreate)
 	if err != nil {
@@ -75,10 +133,38 @@ func resourceCloudcaPublicIPRead(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error reading Trigger: %s", err)
 	}
 
+	if err := d.Set("network_id", publicIP.NetworkId); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := setValueOrID(d, "zone", strings.ToLower(publicIP.ZoneName), publicIP.ZoneId); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
 	if err := d.Set("ip_address", publicIP.IpAddress); err != nil {
 		return fmt.Errorf("Error reading Trigger: %s", err)
 	}
 
+	if err := d.Set("state", publicIP.State); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("purposes", publicIP.Purposes); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("ports", publicIP.Ports); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("instance_id", publicIP.InstanceId); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("zone_name", publicIP.ZoneName); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
 	return nil
 }
 
@@ -1,11 +1,16 @@
 package cloudca
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/api"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -93,3 +98,94 @@ func testAccCheckPublicIPCreateDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestResourceCloudcaPublicIPCreateInNetwork(t *testing.T) {
+	cases := []struct {
+		zone   string
+		zoneID string
+	}{
+		{"QC-2", "zone-id"},
+		{"1c9b2a8e-6f5c-4b8e-9a31-3f4e0b7c2d10", "1c9b2a8e-6f5c-4b8e-9a31-3f4e0b7c2d10"},
+	}
+	for _, c := range cases {
+		client, apiClient := newFakeCcaClient()
+		apiClient.putEntity(cloudca.ZONE_ENTITY_TYPE, "zone-id", cloudca.Zone{Id: "zone-id", Name: "QC-2"})
+
+		d := schema.TestResourceDataRaw(t, resourceCloudcaPublicIP().Schema, map[string]interface{}{
+			"environment_id": fakeEnvironmentID,
+			"network_id":     "network-id",
+			"zone":           c.zone,
+		})
+		if err := resourceCloudcaPublicIPCreate(d, client); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		requests := apiClient.entityRequests(api.POST, cloudca.PUBLIC_IP_ENTITY_TYPE)
+		if len(requests) != 1 {
+			t.Fatalf("Expected the public IP to be acquired once, got %d requests", len(requests))
+		}
+		body := cloudca.PublicIp{}
+		if err := json.Unmarshal(requests[0].Body, &body); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if body.NetworkId != "network-id" || body.VpcId != "" || body.ZoneId != c.zoneID {
+			t.Fatalf("Unexpected request body: %s", requests[0].Body)
+		}
+		assertStateAttributes(t, d, map[string]string{
+			"network_id": "network-id",
+		})
+	}
+}
+
+func TestResourceCloudcaPublicIPRequiresVpcOrNetwork(t *testing.T) {
+	cases := []struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		{map[string]interface{}{"environment_id": fakeEnvironmentID, "vpc_id": "vpc-id"}, true},
+		{map[string]interface{}{"environment_id": fakeEnvironmentID, "network_id": "network-id", "zone": "QC-2"}, true},
+		{map[string]interface{}{"environment_id": fakeEnvironmentID}, false},
+		{map[string]interface{}{"environment_id": fakeEnvironmentID, "vpc_id": "vpc-id", "network_id": "network-id"}, false},
+	}
+	for _, c := range cases {
+		diags := resourceCloudcaPublicIP().Validate(terraform.NewResourceConfigRaw(c.config))
+		if valid := !diags.HasError(); valid != c.valid {
+			t.Fatalf("Expected %v to be valid: %t, got %v", c.config, c.valid, diags)
+		}
+	}
+}
+
+func TestResourceCloudcaPublicIPZoneIsLowercased(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"environment_id": fakeEnvironmentID,
+		"network_id":     "network-id",
+		"zone":           "QC-2",
+	})
+	diff, err := resourceCloudcaPublicIP().Diff(context.Background(), nil, config, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if zone := diff.Attributes["zone"]; zone == nil || zone.New != "qc-2" {
+		t.Fatalf("Expected the zone to be planned as qc-2, got %v", zone)
+	}
+
+	client, apiClient := newFakeCcaClient()
+	apiClient.putEntity(cloudca.PUBLIC_IP_ENTITY_TYPE, "public-ip-id", cloudca.PublicIp{
+		Id:        "public-ip-id",
+		NetworkId: "network-id",
+		ZoneId:    "zone-id",
+		ZoneName:  "QC-2",
+	})
+	d := schema.TestResourceDataRaw(t, resourceCloudcaPublicIP().Schema, map[string]interface{}{
+		"environment_id": fakeEnvironmentID,
+		"zone":           "QC-2",
+	})
+	d.SetId("public-ip-id")
+	if err := resourceCloudcaPublicIPRead(d, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertStateAttributes(t, d, map[string]string{
+		"zone":      "qc-2",
+		"zone_name": "QC-2",
+	})
+}
@@ -1,11 +1,27 @@
 package cloudca
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"log"
+	"strings"
 
 	cca "github.com/cloud-ca/go-cloudca"
 	"github.com/cloud-ca/go-cloudca/services/cloudca"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/crypto/ssh"
+)
+
+// algorithms of generated SSH keys
+const (
+	SSHKeyAlgorithmRSA     = "RSA"
+	SSHKeyAlgorithmED25519 = "ED25519"
 )
 
 func resourceCloudcaSSHKey() *schema.Resource {
@@ -18,6 +34,8 @@ func resourceCloudcaSSHKey() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: customizeSSHKeyDiff,
+
 		Schema: map[string]*schema.Schema{
 			"environment_id": {
 				Type:        schema.TypeString,
@@ -32,9 +50,40 @@ func resourceCloudcaSSHKey() *schema.Resource {
 				Description: "Name of the SSH Key",
 			},
 			"public_key": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The public key in the authorized_keys format. If not set, a new key pair is generated",
+				ValidateFunc:  validateSSHPublicKey,
+				ConflictsWith: []string{"key_algorithm", "rsa_bits"},
+			},
+			"key_algorithm": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The algorithm of the generated key pair (i.e. RSA or ED25519). Defaults to RSA",
+				ValidateFunc:  validation.StringInSlice([]string{SSHKeyAlgorithmRSA, SSHKeyAlgorithmED25519}, true),
+				ConflictsWith: []string{"public_key"},
+			},
+			"rsa_bits": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The size of the generated RSA key pair. Defaults to 4096",
+				ValidateFunc:  validation.IntBetween(2048, 8192),
+				ConflictsWith: []string{"public_key"},
+			},
+			"private_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The private key of the generated key pair in PEM format",
+			},
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The fingerprint of the public key",
 			},
 		},
 	}
@@ -49,6 +98,17 @@ func createSSHKey(d *schema.ResourceData, meta interface{}) error {
 	name := d.Get("name").(string)
 	publicKey := d.Get("public_key").(string)
 
+	if publicKey == "" {
+		generatedPublicKey, privateKey, err := generateSSHKeyPair(d.Get("key_algorithm").(string), d.Get("rsa_bits").(int))
+		if err != nil {
+			return fmt.Errorf("Error generating SSH key pair %s: %s", name, err)
+		}
+		publicKey = generatedPublicKey
+		if err := d.Set("private_key", privateKey); err != nil {
+			return fmt.Errorf("Error setting private_key: %s", err)
+		}
+	}
+
 	sk := cloudca.SSHKey{
 		Name:      name,
 		PublicKey: publicKey,
@@ -58,6 +118,9 @@ func createSSHKey(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error creating new SSH key %s", err)
 	}
 	d.SetId(newSk.ID)
+	if err := d.Set("public_key", publicKey); err != nil {
+		return fmt.Errorf("Error setting public_key: %s", err)
+	}
 	return readSSHKey(d, meta)
 }
 
@@ -78,6 +141,22 @@ func readSSHKey(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error reading Trigger: %s", err)
 	}
 
+	if err := d.Set("fingerprint", sk.Fingerprint); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	// The public key returned by the API may be formatted differently, only replace the
+	// public key of the state when it's not the same key anymore.
+	publicKey := d.Get("public_key").(string)
+	if publicKey == "" || !sshKeyFingerprintMatches(publicKey, sk.Fingerprint) {
+		if publicKey != "" {
+			log.Printf("[WARN] The fingerprint of SSH key %s changed to %s, it was replaced outside of terraform", sk.Name, sk.Fingerprint)
+		}
+		if err := d.Set("public_key", sk.PublicKey); err != nil {
+			return fmt.Errorf("Error reading Trigger: %s", err)
+		}
+	}
+
 	return nil
 }
 
@@ -94,3 +173,88 @@ func deleteSSHKey(d *schema.ResourceData, meta interface{}) error {
 
 	return nil
 }
+
+// Recreates a generated key pair when the public key registered in the environment no longer
+// matches its private key. Supplied public keys are already recreated by the diff of public_key.
+func customizeSSHKeyDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	privateKey := d.Get("private_key").(string)
+	if d.Id() == "" || privateKey == "" || d.HasChange("public_key") {
+		return nil
+	}
+	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return fmt.Errorf("Error parsing the private key of SSH key %s: %s", d.Get("name"), err)
+	}
+	generatedPublicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+	if sshKeyFingerprintMatches(generatedPublicKey, d.Get("fingerprint").(string)) {
+		return nil
+	}
+	log.Printf("[DEBUG] SSH key %s doesn't match its generated private key anymore, it will be recreated", d.Get("name"))
+	if err := d.SetNew("public_key", generatedPublicKey); err != nil {
+		return err
+	}
+	return d.ForceNew("public_key")
+}
+
+func validateSSHPublicKey(val interface{}, key string) (warns []string, errs []error) {
+	if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(val.(string))); err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a public key in the authorized_keys format: %s", key, err))
+	}
+	return
+}
+
+// Returns true if the fingerprint is the MD5 or SHA256 fingerprint of the public key. An empty
+// fingerprint can't be verified and is considered a match.
+func sshKeyFingerprintMatches(publicKey string, fingerprint string) bool {
+	if fingerprint == "" {
+		return true
+	}
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(ssh.FingerprintLegacyMD5(parsed), fingerprint) ||
+		ssh.FingerprintSHA256(parsed) == fingerprint
+}
+
+// Generates a new key pair, returns the public key in the authorized_keys format and the
+// private key in PEM format.
+func generateSSHKeyPair(algorithm string, rsaBits int) (string, string, error) {
+	var publicKey interface{}
+	var privateKeyPEM []byte
+	switch strings.ToUpper(algorithm) {
+	case SSHKeyAlgorithmED25519:
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", "", err
+		}
+		// OpenSSH only reads ed25519 private keys in its own format
+		block, err := ssh.MarshalPrivateKey(priv, "")
+		if err != nil {
+			return "", "", err
+		}
+		privateKeyPEM = pem.EncodeToMemory(block)
+		publicKey = pub
+	case "", SSHKeyAlgorithmRSA:
+		if rsaBits == 0 {
+			rsaBits = 4096
+		}
+		priv, err := rsa.GenerateKey(rand.Reader, rsaBits)
+		if err != nil {
+			return "", "", err
+		}
+		privateKeyPEM = pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(priv),
+		})
+		publicKey = &priv.PublicKey
+	default:
+		return "", "", fmt.Errorf("Unsupported key algorithm %s", algorithm)
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPublicKey))), string(privateKeyPEM), nil
+}
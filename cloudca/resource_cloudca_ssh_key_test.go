@@ -35,6 +35,55 @@ func TestAccSSHKeyCreate(t *testing.T) {
 	})
 }
 
+func TestAccSSHKeyGenerate(t *testing.T) {
+	t.Parallel()
+
+	sshKeyName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSSHKeyCreateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSSHKeyGenerate(environmentID, sshKeyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSSHKeyCreateExists("cloudca_ssh_key.foobar"),
+					resource.TestCheckResourceAttrSet("cloudca_ssh_key.foobar", "public_key"),
+					resource.TestCheckResourceAttrSet("cloudca_ssh_key.foobar", "private_key"),
+					resource.TestCheckResourceAttrSet("cloudca_ssh_key.foobar", "fingerprint"),
+				),
+			},
+		},
+	})
+}
+
+func TestGenerateSSHKeyPair(t *testing.T) {
+	for _, algorithm := range []string{SSHKeyAlgorithmRSA, SSHKeyAlgorithmED25519} {
+		publicKey, privateKey, err := generateSSHKeyPair(algorithm, 2048)
+		if err != nil {
+			t.Fatalf("Error generating %s key pair: %s", algorithm, err)
+		}
+		signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+		if err != nil {
+			t.Fatalf("Error parsing the generated %s private key: %s", algorithm, err)
+		}
+		derivedPublicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+		if derivedPublicKey != publicKey {
+			t.Fatalf("The public key of the %s private key is %s, expected %s", algorithm, derivedPublicKey, publicKey)
+		}
+	}
+}
+
+func testAccSSHKeyGenerate(environment, name string) string {
+	return fmt.Sprintf(`
+resource "cloudca_ssh_key" "foobar" {
+	environment_id = "%s"
+	name           = "%s"
+	key_algorithm  = "ED25519"
+}`, environment, name)
+}
+
 func testAccSSHKeyCreate(environment, name string) string {
 	bitSize := 4096
 
@@ -0,0 +1,250 @@
+package cloudca
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudcaTemplate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudcaTemplateCreate,
+		Read:   resourceCloudcaTemplateRead,
+		Delete: resourceCloudcaTemplateDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of environment where the template should be registered",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the template",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Description of the template",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "URL from which the image of the template is downloaded",
+			},
+			"format": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Format of the image (e.g. QCOW2, RAW, VHD, OVA)",
+			},
+			"hypervisor": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Hypervisor of the image (e.g. KVM, XenServer, VMware)",
+			},
+			"os_type_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the OS type of the template",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Zone ID or name where the template is registered. If not set, the template is registered in all zones",
+			},
+			"extractable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Whether the template can be extracted",
+			},
+			"ssh_key_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Whether the template supports SSH keys",
+			},
+			"password_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Whether the template supports generated passwords",
+			},
+			"os_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The OS type of the template",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the template in bytes",
+			},
+			"ready": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the template is ready to be used",
+			},
+			"available_in_zones": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The zones in which the template is available",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceCloudcaTemplateCreate(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	templateToCreate := cloudca.Template{
+		Name:             d.Get("name").(string),
+		Description:      d.Get("description").(string),
+		URL:              d.Get("url").(string),
+		Format:           d.Get("format").(string),
+		Hypervisor:       d.Get("hypervisor").(string),
+		OSTypeID:         d.Get("os_type_id").(string),
+		Extractable:      d.Get("extractable").(bool),
+		SSHKeyEnabled:    d.Get("ssh_key_enabled").(bool),
+		PassowordEnabled: d.Get("password_enabled").(bool),
+	}
+	if templateToCreate.Description == "" {
+		templateToCreate.Description = templateToCreate.Name
+	}
+
+	if zone, ok := d.GetOk("zone"); ok {
+		if isID(zone.(string)) {
+			templateToCreate.ZoneID = zone.(string)
+		} else {
+			zoneID, zErr := retrieveZoneID(&ccaResources, zone.(string))
+			if zErr != nil {
+				return zErr
+			}
+			templateToCreate.ZoneID = zoneID
+		}
+	}
+
+	newTemplate, err := ccaResources.Templates.Create(templateToCreate)
+	if err != nil {
+		return fmt.Errorf("Error registering the new template %s: %s", templateToCreate.Name, err)
+	}
+	d.SetId(newTemplate.ID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"ready"},
+		Refresh: func() (interface{}, string, error) {
+			template, gErr := ccaResources.Templates.Get(d.Id())
+			if gErr != nil {
+				return nil, "", gErr
+			}
+			if template.Ready {
+				return template, "ready", nil
+			}
+			return template, "pending", nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+	log.Printf("[DEBUG] Waiting for template %s to be ready", d.Id())
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for template %s to be ready: %s", d.Id(), err)
+	}
+
+	return resourceCloudcaTemplateRead(d, meta)
+}
+
+func resourceCloudcaTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+	template, err := ccaResources.Templates.Get(d.Id())
+	if err != nil {
+		return handleNotFoundError("Template", false, err, d)
+	}
+
+	if err := d.Set("name", template.Name); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("description", template.Description); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("os_type_id", template.OSTypeID); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("os_type", template.OSType); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("extractable", template.Extractable); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("ssh_key_enabled", template.SSHKeyEnabled); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("password_enabled", template.PassowordEnabled); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("size", template.Size); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("ready", template.Ready); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("available_in_zones", template.AvailableInZones); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudcaTemplateDelete(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+	if _, err := ccaResources.Templates.Delete(d.Id()); err != nil {
+		return handleNotFoundError("Template", true, err, d)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package cloudca
+
+import (
+	"fmt"
+	"testing"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccTemplateCreate(t *testing.T) {
+	t.Parallel()
+
+	templateName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTemplateCreateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTemplateCreate(environmentID, templateName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTemplateCreateExists("cloudca_template.foobar"),
+					resource.TestCheckResourceAttr("cloudca_template.foobar", "ready", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTemplateCreate(environment, name string) string {
+	return fmt.Sprintf(`
+resource "cloudca_template" "foobar" {
+	environment_id  = "%s"
+	name            = "%s"
+	url             = "http://dl-cdn.alpinelinux.org/alpine/v3.14/releases/cloud/alpine-virt-3.14.2-x86_64.qcow2"
+	format          = "QCOW2"
+	hypervisor      = "KVM"
+	os_type_id      = "6b2ad7a4-4b1d-4e3b-9a8e-0c7a8b2b4f1e"
+	ssh_key_enabled = true
+}`, environment, name)
+}
+
+func testAccCheckTemplateCreateExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		if rs.Primary.Attributes["environment_id"] == "" {
+			return fmt.Errorf("Environment ID is missing")
+		}
+
+		client := testAccProvider.Meta().(*cca.CcaClient)
+		resources, err := getResourcesForEnvironmentID(client, rs.Primary.Attributes["environment_id"])
+		if err != nil {
+			return err
+		}
+
+		found, err := resources.Templates.Get(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("Template not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckTemplateCreateDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*cca.CcaClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "cloudca_template" {
+			if rs.Primary.Attributes["environment_id"] == "" {
+				return fmt.Errorf("Environment ID is missing")
+			}
+
+			resources, err := getResourcesForEnvironmentID(client, rs.Primary.Attributes["environment_id"])
+			if err != nil {
+				return err
+			}
+
+			_, err = resources.Templates.Get(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("Template still exists")
+			}
+		}
+	}
+
+	return nil
+}
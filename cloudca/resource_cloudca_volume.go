@@ -57,8 +57,9 @@ func resourceCloudcaVolume() *schema.Resource {
 			},
 			"instance_id": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The id of the instance to which the volume will be attached",
+				Optional:    true,
+				Computed:    true,
+				Description: "The id of the instance to which the volume will be attached. Use cloudca_volume_attachment to manage the attachment separately",
 			},
 		},
 	}
@@ -161,7 +162,8 @@ func resourceCloudcaVolumeUpdate(d *schema.ResourceData, meta interface{}) error
 	if err != nil {
 		return err
 	}
-	if d.HasChange("instance_id") {
+	// When instance_id isn't configured, the attachment is managed by a cloudca_volume_attachment
+	if d.HasChange("instance_id") && !d.GetRawConfig().GetAttr("instance_id").IsNull() {
 		oldInstanceID, newInstanceID := d.GetChange("instance_id")
 		volume := &cloudca.Volume{
 			Id: d.Id(),
@@ -204,13 +206,14 @@ func resourceCloudcaVolumeDelete(d *schema.ResourceData, meta interface{}) error
 	if rerr != nil {
 		return rerr
 	}
-	if instanceID, ok := d.GetOk("instance_id"); ok && instanceID != "" {
-		volume := &cloudca.Volume{
-			Id: d.Id(),
-		}
-		err := ccaResources.Volumes.DetachFromInstance(volume)
-		if err != nil {
-			return err
+	// The volume may already have been detached, e.g. by the destroy of a cloudca_volume_attachment
+	curVolume, err := ccaResources.Volumes.Get(d.Id())
+	if err != nil {
+		return handleNotFoundError("Volume", true, err, d)
+	}
+	if curVolume.InstanceId != "" {
+		if err := ccaResources.Volumes.DetachFromInstance(curVolume); err != nil {
+			return fmt.Errorf("Error detaching volume %s from instance %s: %s", d.Id(), curVolume.InstanceId, err)
 		}
 	}
 	if err := ccaResources.Volumes.Delete(d.Id()); err != nil {
@@ -0,0 +1,160 @@
+package cloudca
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudcaVolumeAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudcaVolumeAttachmentCreate,
+		Read:   resourceCloudcaVolumeAttachmentRead,
+		Update: resourceCloudcaVolumeAttachmentUpdate,
+		Delete: resourceCloudcaVolumeAttachmentDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of environment of the volume and instance",
+			},
+			"volume_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the volume to attach",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the instance to which the volume will be attached",
+			},
+			"stop_instance_before_detach": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Stop the instance before detaching the volume and start it again afterwards",
+			},
+			"skip_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Don't detach the volume on destroy, only remove the attachment from the state",
+			},
+		},
+	}
+}
+
+func resourceCloudcaVolumeAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	volumeID := d.Get("volume_id").(string)
+	instanceID := d.Get("instance_id").(string)
+	log.Printf("[DEBUG] Attaching volume %s to instance %s", volumeID, instanceID)
+	if err := ccaResources.Volumes.AttachToInstance(&cloudca.Volume{Id: volumeID}, instanceID); err != nil {
+		return fmt.Errorf("Error attaching volume %s to instance %s: %s", volumeID, instanceID, err)
+	}
+
+	d.SetId(volumeID)
+	return resourceCloudcaVolumeAttachmentRead(d, meta)
+}
+
+func resourceCloudcaVolumeAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	volume, err := ccaResources.Volumes.Get(d.Id())
+	if err != nil {
+		return handleNotFoundError("Volume attachment", false, err, d)
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	if instanceID != "" && !strings.EqualFold(volume.InstanceId, instanceID) {
+		// The volume was detached or attached to another instance, so this attachment
+		// is "missing" (at least as far as terraform is concerned).
+		log.Printf("[DEBUG] Volume %s is no longer attached to instance %s", d.Id(), instanceID)
+		d.SetId("")
+		return nil
+	}
+	if volume.InstanceId == "" {
+		// Imported volume that isn't attached
+		return fmt.Errorf("Volume %s is not attached to an instance", d.Id())
+	}
+
+	if err := d.Set("volume_id", volume.Id); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	if err := d.Set("instance_id", volume.InstanceId); err != nil {
+		return fmt.Errorf("Error reading Trigger: %s", err)
+	}
+
+	return nil
+}
+
+func resourceCloudcaVolumeAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	// Only stop_instance_before_detach and skip_destroy can be updated, they are only used on destroy
+	return resourceCloudcaVolumeAttachmentRead(d, meta)
+}
+
+func resourceCloudcaVolumeAttachmentDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	if d.Get("skip_destroy").(bool) {
+		log.Printf("[INFO] Skipping detach of volume %s, removing the attachment from the state", d.Id())
+		return nil
+	}
+
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	if d.Get("stop_instance_before_detach").(bool) {
+		instance, ierr := ccaResources.Instances.Get(instanceID)
+		if ierr != nil {
+			return handleNotFoundError("Volume attachment", true, ierr, d)
+		}
+		if instance.IsRunning() {
+			log.Printf("[DEBUG] Stopping instance %s before detaching volume %s", instanceID, d.Id())
+			if _, err := ccaResources.Instances.Stop(instanceID); err != nil {
+				return fmt.Errorf("Error stopping instance %s: %s", instanceID, err)
+			}
+			// The instance is started again even if the volume could not be detached
+			defer func() {
+				log.Printf("[DEBUG] Starting instance %s after detaching volume %s", instanceID, d.Id())
+				if _, startErr := ccaResources.Instances.Start(instanceID); startErr != nil {
+					if err != nil {
+						err = fmt.Errorf("%s, and error starting instance %s: %s", err, instanceID, startErr)
+					} else {
+						err = fmt.Errorf("Error starting instance %s: %s", instanceID, startErr)
+					}
+				}
+			}()
+		}
+	}
+
+	log.Printf("[DEBUG] Detaching volume %s from instance %s", d.Id(), instanceID)
+	if err := ccaResources.Volumes.DetachFromInstance(&cloudca.Volume{Id: d.Id()}); err != nil {
+		return handleNotFoundError("Volume attachment", true, err, d)
+	}
+	return nil
+}
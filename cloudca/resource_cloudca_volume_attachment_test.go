@@ -0,0 +1,107 @@
+package cloudca
+
+import (
+	"fmt"
+	"testing"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVolumeAttachmentCreate(t *testing.T) {
+	t.Parallel()
+
+	instanceID := "6f26111d-464d-4fc8-9c72-7a181a96c257"
+	volumeName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVolumeAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVolumeAttachmentCreate(environmentID, instanceID, diskOfferingID, volumeName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVolumeAttachmentExists("cloudca_volume_attachment.foobar"),
+					resource.TestCheckResourceAttr("cloudca_volume_attachment.foobar", "instance_id", instanceID),
+				),
+			},
+		},
+	})
+}
+
+func testAccVolumeAttachmentCreate(environment, instance, diskOffering, name string) string {
+	return fmt.Sprintf(`
+resource "cloudca_volume" "foobar" {
+	environment_id = "%s"
+	name           = "%s"
+	disk_offering  = "%s"
+	size_in_gb     = "10"
+}
+
+resource "cloudca_volume_attachment" "foobar" {
+	environment_id = "%s"
+	volume_id      = cloudca_volume.foobar.id
+	instance_id    = "%s"
+}`, environment, name, diskOffering, environment, instance)
+}
+
+func testAccCheckVolumeAttachmentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		if rs.Primary.Attributes["environment_id"] == "" {
+			return fmt.Errorf("Environment ID is missing")
+		}
+
+		client := testAccProvider.Meta().(*cca.CcaClient)
+		resources, err := getResourcesForEnvironmentID(client, rs.Primary.Attributes["environment_id"])
+		if err != nil {
+			return err
+		}
+
+		found, err := resources.Volumes.Get(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.InstanceId != rs.Primary.Attributes["instance_id"] {
+			return fmt.Errorf("Volume is not attached to instance %s", rs.Primary.Attributes["instance_id"])
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVolumeAttachmentDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*cca.CcaClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "cloudca_volume_attachment" {
+			if rs.Primary.Attributes["environment_id"] == "" {
+				return fmt.Errorf("Environment ID is missing")
+			}
+
+			resources, err := getResourcesForEnvironmentID(client, rs.Primary.Attributes["environment_id"])
+			if err != nil {
+				return err
+			}
+
+			found, err := resources.Volumes.Get(rs.Primary.ID)
+			if err == nil && found.InstanceId != "" {
+				return fmt.Errorf("Volume is still attached")
+			}
+		}
+	}
+
+	return nil
+}
@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -31,6 +33,41 @@ func TestAccVolumeCreate(t *testing.T) {
 	})
 }
 
+func TestResourceCloudcaVolumeDeleteAfterAttachmentDestroy(t *testing.T) {
+	client, apiClient := newFakeCcaClient()
+	apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "volume-id", cloudca.Volume{Id: "volume-id", InstanceId: "instance-id"})
+
+	attachment := schema.TestResourceDataRaw(t, resourceCloudcaVolumeAttachment().Schema, map[string]interface{}{
+		"environment_id": fakeEnvironmentID,
+		"volume_id":      "volume-id",
+		"instance_id":    "instance-id",
+	})
+	attachment.SetId("volume-id")
+	if err := resourceCloudcaVolumeAttachmentDelete(attachment, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	// the fake API doesn't apply operations, the volume is detached as the API would
+	apiClient.putEntity(cloudca.VOLUME_ENTITY_TYPE, "volume-id", cloudca.Volume{Id: "volume-id"})
+
+	// the state of the volume still has the instance it was attached to
+	volume := schema.TestResourceDataRaw(t, resourceCloudcaVolume().Schema, map[string]interface{}{
+		"environment_id": fakeEnvironmentID,
+		"name":           "volume",
+		"instance_id":    "instance-id",
+	})
+	volume.SetId("volume-id")
+	if err := resourceCloudcaVolumeDelete(volume, client); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if detachRequests := apiClient.operationRequests("detachFromInstance"); len(detachRequests) != 1 {
+		t.Fatalf("Expected the volume to be detached once, got %d detach requests", len(detachRequests))
+	}
+	if apiClient.hasEntity(cloudca.VOLUME_ENTITY_TYPE, "volume-id") {
+		t.Fatalf("Expected the volume to be deleted")
+	}
+}
+
 func testAccVolumeCreate(environment, instance, diskOffering, name string) string {
 	return fmt.Sprintf(`
 resource "cloudca_volume" "foobar" {
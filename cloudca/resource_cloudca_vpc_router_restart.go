@@ -0,0 +1,106 @@
+package cloudca
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	cca "github.com/cloud-ca/go-cloudca"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// state of a VPC once its router is ready
+const vpcStateEnabled = "Enabled"
+
+func resourceCloudcaVpcRouterRestart() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudcaVpcRouterRestartCreate,
+		Read:   resourceCloudcaVpcRouterRestartRead,
+		Delete: resourceCloudcaVpcRouterRestartDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of environment of the VPC",
+			},
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the VPC whose router should be restarted",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, will restart the router again",
+			},
+		},
+	}
+}
+
+func resourceCloudcaVpcRouterRestartCreate(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	vpcID := d.Get("vpc_id").(string)
+	log.Printf("[DEBUG] Restarting router of VPC %s", vpcID)
+	if _, err := ccaResources.Vpcs.RestartRouter(vpcID); err != nil {
+		return fmt.Errorf("Error restarting router of VPC %s: %s", vpcID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{vpcStateEnabled},
+		Refresh: func() (interface{}, string, error) {
+			vpc, gErr := ccaResources.Vpcs.Get(vpcID)
+			if gErr != nil {
+				return nil, "", gErr
+			}
+			if strings.EqualFold(vpc.State, vpcStateEnabled) {
+				return vpc, vpcStateEnabled, nil
+			}
+			return vpc, "pending", nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	log.Printf("[DEBUG] Waiting for VPC %s to be enabled", vpcID)
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for VPC %s to be enabled: %s", vpcID, err)
+	}
+
+	d.SetId(resource.PrefixedUniqueId(vpcID + "-"))
+	return resourceCloudcaVpcRouterRestartRead(d, meta)
+}
+
+func resourceCloudcaVpcRouterRestartRead(d *schema.ResourceData, meta interface{}) error {
+	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
+
+	if rerr != nil {
+		return rerr
+	}
+
+	if _, err := ccaResources.Vpcs.Get(d.Get("vpc_id").(string)); err != nil {
+		return handleNotFoundError("VPC router restart", true, err, d)
+	}
+	return nil
+}
+
+func resourceCloudcaVpcRouterRestartDelete(d *schema.ResourceData, meta interface{}) error {
+	// A restart can't be undone, it is only removed from the state
+	d.SetId("")
+	return nil
+}
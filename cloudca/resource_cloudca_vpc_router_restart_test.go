@@ -0,0 +1,53 @@
+package cloudca
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVpcRouterRestart(t *testing.T) {
+	t.Parallel()
+
+	vpcName := fmt.Sprintf("terraform-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVPCCreateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcRouterRestart(environmentID, vpcName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCCreateExists("cloudca_vpc.foobar"),
+					resource.TestCheckResourceAttrSet("cloudca_vpc_router_restart.foobar", "id"),
+				),
+			},
+			{
+				Config: testAccVpcRouterRestart(environmentID, vpcName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudca_vpc_router_restart.foobar", "triggers.revision", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVpcRouterRestart(environment, name, revision string) string {
+	return fmt.Sprintf(`
+resource "cloudca_vpc" "foobar" {
+	environment_id = "%s"
+	name           = "%s"
+	description    = "This is a %s vpc"
+	vpc_offering   = "Default VPC offering"
+}
+resource "cloudca_vpc_router_restart" "foobar" {
+	environment_id = "%s"
+	vpc_id         = cloudca_vpc.foobar.id
+	triggers = {
+		revision = "%s"
+	}
+}`, environment, name, name, environment, revision)
+}
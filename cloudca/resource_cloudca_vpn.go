@@ -6,6 +6,7 @@ import (
 
 	cca "github.com/cloud-ca/go-cloudca"
 	"github.com/cloud-ca/go-cloudca/api"
+	"github.com/cloud-ca/go-cloudca/services/cloudca"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -73,33 +74,20 @@ func resourceCloudcaVpn() *schema.Resource {
 }
 
 func resourceCloudcaVpnCreate(d *schema.ResourceData, meta interface{}) error {
-	vpnIPPurpose := "SOURCE_NAT"
 	ccaResources, rerr := getResourcesForEnvironmentID(meta.(*cca.CcaClient), d.Get("environment_id").(string))
 	if rerr != nil {
 		return rerr
 	}
 
-	var vpnPubIPID string
-	pubIps, _ := ccaResources.PublicIps.List()
-	for _, ip := range pubIps {
-		if ip.VpcId == d.Get("vpc_id").(string) {
-			for _, purpose := range ip.Purposes {
-				if purpose == vpnIPPurpose {
-					vpnPubIPID = ip.Id
-					break
-				}
-			}
-		}
-		if vpnPubIPID != "" {
-			break
-		}
+	vpnPubIPID, err := retrieveVpnPublicIPID(&ccaResources, d.Get("vpc_id").(string))
+	if err != nil {
+		return err
 	}
-
 	if vpnPubIPID == "" {
 		return fmt.Errorf("Error enabling the VPN because no Source NAT IP was found for the VPC")
 	}
 
-	_, err := ccaResources.RemoteAccessVpn.Enable(vpnPubIPID)
+	_, err = ccaResources.RemoteAccessVpn.Enable(vpnPubIPID)
 	if err != nil {
 		return fmt.Errorf("Error enabling the VPN: %s", err)
 	}
@@ -163,3 +151,22 @@ func resourceCloudcaVpnDelete(d *schema.ResourceData, meta interface{}) error {
 	}
 	return nil
 }
+
+// The VPN of a VPC is identified by the ID of the Source NAT public IP of the VPC.
+func retrieveVpnPublicIPID(ccaRes *cloudca.Resources, vpcID string) (string, error) {
+	vpnIPPurpose := "SOURCE_NAT"
+	pubIps, err := ccaRes.PublicIps.List()
+	if err != nil {
+		return "", fmt.Errorf("Error listing the public IPs of the VPC %s: %s", vpcID, err)
+	}
+	for _, ip := range pubIps {
+		if ip.VpcId == vpcID {
+			for _, purpose := range ip.Purposes {
+				if purpose == vpnIPPurpose {
+					return ip.Id, nil
+				}
+			}
+		}
+	}
+	return "", nil
+}